@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// BoundedSchedule limits another schedule to a date range and a maximum number of runs.
+// Once the bounds are exhausted NextRun returns nil, so the task stops being scheduled.
+//
+// MaxRuns counts the runs the wrapped schedule produces from NotBefore onwards, whether or not the
+// task actually ran at them, so it requires NotBefore; LimitRuns sets both.
+type BoundedSchedule struct {
+	Schedule  TimeSchedule
+	NotBefore time.Time // Optional; runs before it are dropped.
+	NotAfter  time.Time // Optional; runs after it are dropped.
+	MaxRuns   int       // Optional; zero means unlimited.
+}
+
+// LimitRuns bounds schedule to its next maxRuns runs from the given time, such as the Now of the
+// scheduler's Clock.
+func LimitRuns(schedule TimeSchedule, from time.Time, maxRuns int) BoundedSchedule {
+	return BoundedSchedule{Schedule: schedule, NotBefore: from, MaxRuns: maxRuns}
+}
+
+// NextRun returns the next run time after the provided time within the bounds, or nil once they are exhausted.
+// With MaxRuns set, each call counts the runs from NotBefore; the scheduler and Preview step
+// through the runs with a runCursor instead, so that they count each run once.
+func (bounded BoundedSchedule) NextRun(after time.Time) *time.Time {
+	return bounded.nextRun(after, nil)
+}
+
+// nextRun is NextRun resuming the MaxRuns count from cursor, if not nil.
+func (bounded BoundedSchedule) nextRun(after time.Time, cursor *runCursor) *time.Time {
+	if bounded.MaxRuns > 0 && bounded.NotBefore.IsZero() {
+		return nil
+	}
+	searchFrom := after
+	if !bounded.NotBefore.IsZero() && bounded.NotBefore.After(after) {
+		searchFrom = bounded.NotBefore.Add(-time.Nanosecond)
+	}
+	nextRunTime := bounded.Schedule.NextRun(searchFrom)
+	if nextRunTime == nil {
+		return nil
+	}
+	if !bounded.NotAfter.IsZero() && nextRunTime.After(bounded.NotAfter) {
+		return nil
+	}
+	if bounded.MaxRuns > 0 && bounded.runsBefore(*nextRunTime, cursor) >= bounded.MaxRuns {
+		return nil
+	}
+	return nextRunTime
+}
+
+// Description returns a description of the bounded schedule.
+func (bounded BoundedSchedule) Description() string {
+	description := bounded.Schedule.Description()
+	if !bounded.NotBefore.IsZero() {
+		description += ", from " + bounded.NotBefore.Format("2006-01-02 15:04")
+	}
+	if !bounded.NotAfter.IsZero() {
+		description += ", until " + bounded.NotAfter.Format("2006-01-02 15:04")
+	}
+	if bounded.MaxRuns == 1 {
+		description += ", once"
+	} else if bounded.MaxRuns > 1 {
+		description += fmt.Sprintf(", at most %d times", bounded.MaxRuns)
+	}
+	return description
+}
+
+// Validate checks the wrapped schedule and that the bounds are consistent.
+func (bounded BoundedSchedule) Validate() error {
+	if err := validateNested("BoundedSchedule", "Schedule", bounded.Schedule); err != nil {
+		return err
+	}
+	if bounded.MaxRuns < 0 {
+		return &ScheduleFieldError{Schedule: "BoundedSchedule", Field: "MaxRuns", Reason: fmt.Sprintf("must not be negative, got %d", bounded.MaxRuns)}
+	}
+	if bounded.MaxRuns > 0 && bounded.NotBefore.IsZero() {
+		return &ScheduleFieldError{Schedule: "BoundedSchedule", Field: "NotBefore", Reason: "is required when MaxRuns is set"}
+	}
+	if !bounded.NotBefore.IsZero() && !bounded.NotAfter.IsZero() && bounded.NotAfter.Before(bounded.NotBefore) {
+		return &ScheduleFieldError{Schedule: "BoundedSchedule", Field: "NotAfter", Reason: "must not be before NotBefore"}
+	}
+	return nil
+}
+
+// RemainingRuns returns how many runs are left after the provided time, or -1 when MaxRuns is not set.
+// Runs cut off by NotAfter are not subtracted.
+func (bounded BoundedSchedule) RemainingRuns(after time.Time) int {
+	if bounded.MaxRuns <= 0 {
+		return -1
+	}
+	if bounded.NotBefore.IsZero() {
+		return 0
+	}
+	remainingRuns := bounded.MaxRuns - bounded.runsBefore(after.Add(time.Nanosecond), nil)
+	if remainingRuns < 0 {
+		return 0
+	}
+	return remainingRuns
+}
+
+// ExplainNextRun reports how many runs remain and when the schedule ends, such as
+// "3 runs remaining, ends on Mar 31, 2026".
+func (bounded BoundedSchedule) ExplainNextRun(after time.Time) string {
+	note := ""
+	if explainer, ok := bounded.Schedule.(NextRunExplainer); ok {
+		note = explainer.ExplainNextRun(after)
+	}
+	boundsNote := ""
+	if remainingRuns := bounded.RemainingRuns(after); remainingRuns >= 0 {
+		boundsNote = fmt.Sprintf("%s remaining", pluralizeUnit(remainingRuns, "run"))
+		if remainingRuns == 1 {
+			boundsNote = "last run"
+		}
+	}
+	if !bounded.NotAfter.IsZero() {
+		if boundsNote != "" {
+			boundsNote += ", "
+		}
+		boundsNote += "ends on " + bounded.NotAfter.Format("Jan 2, 2006")
+	}
+	if note != "" && boundsNote != "" {
+		return note + "; " + boundsNote
+	}
+	return note + boundsNote
+}
+
+// runCursor remembers how far the runs of a BoundedSchedule have been counted, so that a caller
+// stepping through them in order resumes counting instead of starting over from NotBefore on
+// every call. The zero value has counted nothing.
+type runCursor struct {
+	notBefore time.Time // NotBefore of the schedule the count belongs to.
+	runTime   time.Time // The first run of the wrapped schedule not counted yet.
+	count     int       // Runs from notBefore before runTime.
+}
+
+// nextRunWithCursor returns the next run of schedule after the provided time, counting the
+// MaxRuns of a BoundedSchedule from cursor.
+func nextRunWithCursor(schedule TimeSchedule, after time.Time, cursor *runCursor) *time.Time {
+	if bounded, isBounded := schedule.(BoundedSchedule); isBounded {
+		return bounded.nextRun(after, cursor)
+	}
+	return schedule.NextRun(after)
+}
+
+// runsBefore counts the runs of the wrapped schedule from NotBefore up to, but excluding,
+// the provided time, stopping once MaxRuns is reached. It resumes from cursor, if not nil, when
+// the cursor belongs to the same NotBefore and has not counted past limit, and advances it.
+func (bounded BoundedSchedule) runsBefore(limit time.Time, cursor *runCursor) int {
+	runCount := 0
+	var runTime *time.Time
+	if cursor != nil && !cursor.runTime.IsZero() && cursor.notBefore.Equal(bounded.NotBefore) && !cursor.runTime.After(limit) {
+		resumeTime := cursor.runTime
+		runCount, runTime = cursor.count, &resumeTime
+	} else {
+		runTime = bounded.Schedule.NextRun(bounded.NotBefore.Add(-time.Nanosecond))
+	}
+	for runTime != nil && runTime.Before(limit) && runCount < bounded.MaxRuns {
+		runCount++
+		runTime = bounded.Schedule.NextRun(*runTime)
+	}
+	if cursor != nil && runTime != nil {
+		*cursor = runCursor{notBefore: bounded.NotBefore, runTime: *runTime, count: runCount}
+	}
+	return runCount
+}
@@ -0,0 +1,301 @@
+package scheduler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// businessDaySearchLimit bounds how many consecutive non-business days a calendar may contain.
+const businessDaySearchLimit = 3660
+
+// Calendar decides which days are business days.
+type Calendar interface {
+	// IsBusinessDay reports whether the date of day is a business day. When it is not,
+	// reason explains why, such as "Saturday" or the name of a holiday.
+	IsBusinessDay(day time.Time) (isBusinessDay bool, reason string)
+}
+
+// Holiday is a named non-business day. Only the year, month and day of Date are used.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// HolidayCalendar is a Calendar made of weekend days, holidays and custom exclusion dates.
+type HolidayCalendar struct {
+	Weekend       []time.Weekday // Non-business weekdays; nil means Saturday and Sunday.
+	Holidays      []Holiday
+	ExcludedDates []time.Time // Additional non-business dates, such as a company shutdown.
+}
+
+// IsBusinessDay reports whether the date of day is a business day in the calendar.
+func (calendar HolidayCalendar) IsBusinessDay(day time.Time) (bool, string) {
+	for _, holiday := range calendar.Holidays {
+		if isSameDate(holiday.Date, day) {
+			return false, holiday.Name
+		}
+	}
+	for _, excludedDate := range calendar.ExcludedDates {
+		if isSameDate(excludedDate, day) {
+			return false, "excluded date"
+		}
+	}
+	weekend := calendar.Weekend
+	if weekend == nil {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	if containsWeekday(weekend, day.Weekday()) {
+		return false, day.Weekday().String()
+	}
+	return true, ""
+}
+
+// Validate checks that the calendar leaves at least one weekday as a business day.
+func (calendar HolidayCalendar) Validate() error {
+	if calendar.Weekend == nil {
+		return nil
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if !containsWeekday(calendar.Weekend, weekday) {
+			return nil
+		}
+	}
+	return &ScheduleFieldError{Schedule: "HolidayCalendar", Field: "Weekend", Reason: "must leave at least one business day"}
+}
+
+// LoadHolidayFile reads a holiday list from a local file; see ParseHolidays for the format.
+func LoadHolidayFile(path string) ([]Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseHolidays(file)
+}
+
+// ParseHolidays reads one holiday per line in the form "2026-12-25 Christmas Day".
+// Blank lines and lines starting with '#' are ignored; the name is optional.
+func ParseHolidays(reader io.Reader) ([]Holiday, error) {
+	scanner := bufio.NewScanner(reader)
+	var holidays []Holiday
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dateText, name, _ := strings.Cut(line, " ")
+		date, err := time.Parse("2006-01-02", dateText)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid date %q", ErrInvalidHolidayList, lineNumber, dateText)
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = "holiday"
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// BusinessDayPolicy decides what BusinessDaySchedule does with a run that falls on a non-business day.
+type BusinessDayPolicy int
+
+const (
+	// BusinessDaySkip drops the run.
+	BusinessDaySkip BusinessDayPolicy = iota
+	// BusinessDayShiftForward moves the run to the same time on the next business day.
+	BusinessDayShiftForward
+	// BusinessDayShiftBackward moves the run to the same time on the previous business day.
+	BusinessDayShiftBackward
+)
+
+// BusinessDaySchedule wraps any schedule so that it only runs on the business days of a Calendar.
+// Runs that land on the same instant after shifting are merged into one.
+type BusinessDaySchedule struct {
+	Schedule TimeSchedule
+	Calendar Calendar
+	Policy   BusinessDayPolicy
+	Location *time.Location // Time zone that decides the date of a run; nil uses the location of each run.
+}
+
+// NextRun returns the next run time after the provided time.
+func (business BusinessDaySchedule) NextRun(after time.Time) *time.Time {
+	nextRunTime, _ := business.nextBusinessRun(after)
+	return nextRunTime
+}
+
+// Description returns a description of the business-day schedule.
+func (business BusinessDaySchedule) Description() string {
+	switch business.Policy {
+	case BusinessDayShiftForward:
+		return business.Schedule.Description() + ", moved to the next business day on holidays and weekends"
+	case BusinessDayShiftBackward:
+		return business.Schedule.Description() + ", moved to the previous business day on holidays and weekends"
+	default:
+		return business.Schedule.Description() + ", skipped on holidays and weekends"
+	}
+}
+
+// Validate checks the wrapped schedule, the calendar and the policy.
+func (business BusinessDaySchedule) Validate() error {
+	if err := validateNested("BusinessDaySchedule", "Schedule", business.Schedule); err != nil {
+		return err
+	}
+	if business.Calendar == nil {
+		return &ScheduleFieldError{Schedule: "BusinessDaySchedule", Field: "Calendar", Reason: "is required"}
+	}
+	if validator, ok := business.Calendar.(ScheduleValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("BusinessDaySchedule.Calendar: %w", err)
+		}
+	}
+	if business.Policy < BusinessDaySkip || business.Policy > BusinessDayShiftBackward {
+		return &ScheduleFieldError{Schedule: "BusinessDaySchedule", Field: "Policy", Reason: fmt.Sprintf("holds unknown policy %d", business.Policy)}
+	}
+	return nil
+}
+
+// ExplainNextRun describes why the next run after the provided time differs from the wrapped
+// schedule, such as "shifted from Fri, Dec 25 (Christmas Day)". It returns "" when it does not.
+func (business BusinessDaySchedule) ExplainNextRun(after time.Time) string {
+	nextRunTime, sourceRunTime := business.nextBusinessRun(after)
+	if nextRunTime == nil {
+		return ""
+	}
+	location := business.dateLocation(*nextRunTime)
+	if !isSameDate(nextRunTime.In(location), sourceRunTime.In(location)) {
+		_, reason := business.Calendar.IsBusinessDay(sourceRunTime.In(location))
+		return fmt.Sprintf("shifted from %s (%s)", sourceRunTime.In(location).Format("Mon, Jan 2"), reason)
+	}
+	if business.Policy == BusinessDaySkip {
+		skippedRunTime := business.Schedule.NextRun(after)
+		if skippedRunTime != nil && skippedRunTime.Before(*nextRunTime) {
+			_, reason := business.Calendar.IsBusinessDay(skippedRunTime.In(location))
+			return fmt.Sprintf("skipping %s (%s)", skippedRunTime.In(location).Format("Mon, Jan 2"), reason)
+		}
+	}
+	return ""
+}
+
+// nextBusinessRun returns the next run after the provided time together with the run of the
+// wrapped schedule it was derived from.
+//
+// Each business day collects the wrapped runs of a window of days: the day itself when skipping,
+// the day and the non-business days before it when shifting forward, and the day and the
+// non-business days after it when shifting backward.
+func (business BusinessDaySchedule) nextBusinessRun(after time.Time) (*time.Time, time.Time) {
+	if business.Schedule == nil || business.Calendar == nil {
+		return nil, time.Time{}
+	}
+	location := business.Location
+	if location == nil {
+		location = after.Location()
+		if probeRunTime := business.Schedule.NextRun(after); probeRunTime != nil {
+			location = probeRunTime.Location()
+		}
+	}
+
+	afterDate := civilDate(after.In(location))
+	var businessDate time.Time
+	var found bool
+	if business.Policy == BusinessDayShiftBackward {
+		businessDate, found = business.findBusinessDay(afterDate, -1, location)
+	} else {
+		businessDate, found = business.findBusinessDay(afterDate, 1, location)
+	}
+
+	for found {
+		windowStart, windowEnd := businessDate, businessDate.AddDate(0, 0, 1)
+		switch business.Policy {
+		case BusinessDayShiftForward:
+			if previousBusinessDate, exists := business.findBusinessDay(businessDate.AddDate(0, 0, -1), -1, location); exists {
+				windowStart = previousBusinessDate.AddDate(0, 0, 1)
+			}
+		case BusinessDayShiftBackward:
+			if nextBusinessDate, exists := business.findBusinessDay(businessDate.AddDate(0, 0, 1), 1, location); exists {
+				windowEnd = nextBusinessDate
+			}
+		}
+		windowStartTime := dateIn(windowStart, location)
+		windowEndTime := dateIn(windowEnd, location)
+
+		var earliestRun *time.Time
+		var earliestSource time.Time
+		sourceRunTime := business.Schedule.NextRun(windowStartTime.Add(-time.Nanosecond))
+		for sourceRunTime != nil && sourceRunTime.Before(windowEndTime) {
+			localSource := sourceRunTime.In(location)
+			shiftedRunTime := time.Date(
+				businessDate.Year(), businessDate.Month(), businessDate.Day(),
+				localSource.Hour(), localSource.Minute(), localSource.Second(), localSource.Nanosecond(), location,
+			)
+			if shiftedRunTime.After(after) && (earliestRun == nil || shiftedRunTime.Before(*earliestRun)) {
+				earliestRun = &shiftedRunTime
+				earliestSource = *sourceRunTime
+			}
+			sourceRunTime = business.Schedule.NextRun(*sourceRunTime)
+		}
+		if earliestRun != nil {
+			return earliestRun, earliestSource
+		}
+		if sourceRunTime == nil {
+			return nil, time.Time{}
+		}
+
+		// Jump straight to the business day that collects the next wrapped run.
+		sourceDate := civilDate(sourceRunTime.In(location))
+		if business.Policy == BusinessDayShiftBackward {
+			businessDate, found = business.findBusinessDay(sourceDate, -1, location)
+		} else {
+			businessDate, found = business.findBusinessDay(sourceDate, 1, location)
+		}
+	}
+	return nil, time.Time{}
+}
+
+// findBusinessDay returns the first business day at or after (direction 1) or at or before
+// (direction -1) the civil date start.
+func (business BusinessDaySchedule) findBusinessDay(start time.Time, direction int, location *time.Location) (time.Time, bool) {
+	candidate := start
+	for dayOffset := 0; dayOffset < businessDaySearchLimit; dayOffset++ {
+		if isBusinessDay, _ := business.Calendar.IsBusinessDay(dateIn(candidate, location)); isBusinessDay {
+			return candidate, true
+		}
+		candidate = candidate.AddDate(0, 0, direction)
+	}
+	return time.Time{}, false
+}
+
+// dateLocation returns the time zone that decides the date of the given run.
+func (business BusinessDaySchedule) dateLocation(runTime time.Time) *time.Location {
+	if business.Location != nil {
+		return business.Location
+	}
+	return runTime.Location()
+}
+
+// civilDate returns midnight UTC of the date of t, so that date arithmetic ignores time zones.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dateIn returns the start of the civil date in location.
+func dateIn(date time.Time, location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
+
+// isSameDate reports whether two times fall on the same year, month and day.
+func isSameDate(first time.Time, second time.Time) bool {
+	firstYear, firstMonth, firstDay := first.Date()
+	secondYear, secondMonth, secondDay := second.Date()
+	return firstYear == secondYear && firstMonth == secondMonth && firstDay == secondDay
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"time"
 
@@ -16,43 +17,60 @@ import (
 // Execute processes CLI flags and executes the requested command.
 // This function is intended to be called from the destination project's main package.
 func Execute() {
+	ExecuteWithClock(systemClock{})
+}
+
+// ExecuteWithClock is like Execute but reads the time from clock, which also drives the
+// scheduler started by --start. Tests use it with a FakeClock to check --list and --next output.
+func ExecuteWithClock(clock Clock) {
 	listCommand := flag.Bool("list", false, "List all registered tasks")
 	runCommand := flag.String("run", "", "Run a specific task immediately")
 	startCommand := flag.Bool("start", false, "Start the scheduler with all registered tasks")
 	helpCommand := flag.Bool("help", false, "Show detailed help for tasks")
+	nextCommand := flag.String("next", "", "Preview upcoming runs of a task, or of all tasks with \"all\"")
+	previewCount := flag.Int("n", 10, "Number of upcoming runs to show with --next")
+	previewZone := flag.String("tz", "", "Time zone for --next output, such as Europe/Berlin")
+	previewFrom := flag.String("from", "", "Start of the --next range (YYYY-MM-DD or RFC 3339); defaults to now")
+	previewUntil := flag.String("until", "", "End of the --next range (YYYY-MM-DD or RFC 3339)")
+	completionsPath := flag.String("completions", "", "File recording completed one-time runs; defaults to memory")
 	flag.Parse()
 
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, nil)))
 	// If no flags are provided, default to listing tasks.
-	if !*listCommand && !*helpCommand && *runCommand == "" && !*startCommand {
+	if !*listCommand && !*helpCommand && *runCommand == "" && !*startCommand && *nextCommand == "" {
 		*listCommand = true
 	}
+	completionStore := openCompletionStore(*completionsPath)
 	if *listCommand {
-		listTasks()
+		listTasks(clock, completionStore)
 		return
 	}
 	if *helpCommand {
-		showHelp()
+		showHelp(clock, completionStore)
 		return
 	}
 	if *runCommand != "" {
-		runTaskFromRegistry(*runCommand)
+		runTaskFromRegistry(*runCommand, clock)
 		return
 	}
 	if *startCommand {
-		startScheduler()
+		startScheduler(clock, completionStore)
+		return
+	}
+	if *nextCommand != "" {
+		previewTasks(*nextCommand, *previewCount, *previewZone, *previewFrom, *previewUntil, clock)
 		return
 	}
 }
 
-func listTasks() {
+func listTasks(clock Clock, completionStore CompletionStore) {
 	taskInfos := GetAllTaskInfo()
 	if len(taskInfos) == 0 {
 		fmt.Println("No tasks are currently registered in the scheduler.")
 		fmt.Println("Make sure task modules are properly imported.")
 		return
 	}
-	currentTime := time.Now()
+	currentTime := clock.Now()
 	var validTaskInfos []TaskInfo
 	var invalidTaskIDs []string
 	for _, taskInfo := range taskInfos {
@@ -60,8 +78,7 @@ func listTasks() {
 			invalidTaskIDs = append(invalidTaskIDs, taskInfo.ID)
 			continue
 		}
-		nextRunPtr := taskInfo.Schedule.NextRun(currentTime)
-		if nextRunPtr == nil || !nextRunPtr.After(currentTime) {
+		if pendingRunTime(taskInfo, completionStore, currentTime) == nil {
 			invalidTaskIDs = append(invalidTaskIDs, taskInfo.ID)
 			continue
 		}
@@ -100,18 +117,33 @@ func listTasks() {
 	for _, taskInfo := range validTaskInfos {
 		taskID := taskInfo.ID
 		scheduleDesc := taskInfo.Schedule.Description()
-		nextRunPtr := taskInfo.Schedule.NextRun(currentTime)
-		nextRunDesc := formatNextRunTime(nextRunPtr)
+		nextRunPtr := pendingRunTime(taskInfo, completionStore, currentTime)
+		nextRunLines := []string{formatNextRunTime(nextRunPtr, clock)}
+		if nextRunPtr.Equal(currentTime) {
+			nextRunLines = append(nextRunLines, "overdue, runs on --start")
+		}
+		if explainer, ok := taskInfo.Schedule.(NextRunExplainer); ok {
+			if note := explainer.ExplainNextRun(currentTime); note != "" {
+				nextRunLines = append(nextRunLines, note)
+			}
+		}
 		scheduleLines := utils.WordWrap(scheduleDesc, scheduleWidth)
-		fmt.Printf("%-*s %-*s %-*s\n", taskIDWidth, taskID, scheduleWidth, scheduleLines[0], nextRunWidth, nextRunDesc)
-		for subIndex := 1; subIndex < len(scheduleLines); subIndex++ {
-			fmt.Printf("%-*s %-*s %-*s\n", taskIDWidth, "", scheduleWidth, scheduleLines[subIndex], nextRunWidth, "")
+		fmt.Printf("%-*s %-*s %-*s\n", taskIDWidth, taskID, scheduleWidth, scheduleLines[0], nextRunWidth, nextRunLines[0])
+		for subIndex := 1; subIndex < len(scheduleLines) || subIndex < len(nextRunLines); subIndex++ {
+			scheduleLine, nextRunLine := "", ""
+			if subIndex < len(scheduleLines) {
+				scheduleLine = scheduleLines[subIndex]
+			}
+			if subIndex < len(nextRunLines) {
+				nextRunLine = nextRunLines[subIndex]
+			}
+			fmt.Printf("%-*s %-*s %-*s\n", taskIDWidth, "", scheduleWidth, scheduleLine, nextRunWidth, nextRunLine)
 		}
 	}
 	fmt.Println("\n(Run with --help for more information)")
 }
 
-func runTaskFromRegistry(taskID string) {
+func runTaskFromRegistry(taskID string, clock Clock) {
 	taskInfo, err := GetTaskInfo(taskID)
 	if err != nil {
 		fmt.Printf("Error: Task '%s' is not registered: %v\n", taskID, err)
@@ -136,22 +168,22 @@ func runTaskFromRegistry(taskID string) {
 		fmt.Printf("Task '%s' failed in BeforeExecute: %v\n", taskID, err)
 		os.Exit(1)
 	}
-	startTime := time.Now()
+	startTime := clock.Now()
 	if err := taskInstance.Run(ctx); err != nil {
-		fmt.Printf("Task '%s' failed after %v: %v\n", taskID, time.Since(startTime), err)
+		fmt.Printf("Task '%s' failed after %v: %v\n", taskID, clock.Now().Sub(startTime), err)
 		os.Exit(1)
 	}
-	fmt.Printf("Task '%s' completed successfully in %v.\n", taskID, time.Since(startTime))
+	fmt.Printf("Task '%s' completed successfully in %v.\n", taskID, clock.Now().Sub(startTime))
 }
 
-func startScheduler() {
+func startScheduler(clock Clock, completionStore CompletionStore) {
 	taskInfos := GetAllTaskInfo()
 	if len(taskInfos) == 0 {
 		fmt.Println("No tasks are registered in the scheduler.")
 		fmt.Println("Make sure task modules are properly imported.")
 		return
 	}
-	currentTime := time.Now()
+	currentTime := clock.Now()
 	var validTaskInfos []TaskInfo
 	var invalidTaskIDs []string
 	for _, taskInfo := range taskInfos {
@@ -159,8 +191,7 @@ func startScheduler() {
 			invalidTaskIDs = append(invalidTaskIDs, taskInfo.ID)
 			continue
 		}
-		nextRunPtr := taskInfo.Schedule.NextRun(currentTime)
-		if nextRunPtr == nil || !nextRunPtr.After(currentTime) {
+		if pendingRunTime(taskInfo, completionStore, currentTime) == nil {
 			invalidTaskIDs = append(invalidTaskIDs, taskInfo.ID)
 			continue
 		}
@@ -183,7 +214,8 @@ func startScheduler() {
 		}
 		fmt.Println("")
 	}
-	schedulerInstance := NewScheduler()
+	schedulerInstance := NewScheduler(WithClock(clock))
+	schedulerInstance.SetCompletionStore(completionStore)
 	var registeredTaskIDs []string
 	var failedTaskIDs []string
 	for _, taskInfo := range validTaskInfos {
@@ -219,8 +251,8 @@ func startScheduler() {
 			fmt.Printf("Warning: Could not get info for task '%s': %v\n", taskID, err)
 			continue
 		}
-		nextRunPtr := taskInfo.Schedule.NextRun(currentTime)
-		fmt.Printf("  - %s (next run: %s)\n", taskID, formatNextRunTime(nextRunPtr))
+		nextRunPtr := pendingRunTime(taskInfo, completionStore, currentTime)
+		fmt.Printf("  - %s (next run: %s)\n", taskID, formatNextRunTime(nextRunPtr, clock))
 	}
 	schedulerInstance.Start()
 	sigChan := make(chan os.Signal, 1)
@@ -232,6 +264,90 @@ func startScheduler() {
 	fmt.Println("Scheduler stopped")
 }
 
+func previewTasks(taskSelector string, count int, zoneName string, fromText string, untilText string, clock Clock) {
+	location := time.Local
+	if zoneName != "" {
+		loadedLocation, err := time.LoadLocation(zoneName)
+		if err != nil {
+			fmt.Printf("Error: Unknown time zone '%s': %v\n", zoneName, err)
+			os.Exit(1)
+		}
+		location = loadedLocation
+	}
+	fromTime := clock.Now().In(location)
+	if fromText != "" {
+		parsedTime, err := parsePreviewTime(fromText, location)
+		if err != nil {
+			fmt.Printf("Error: Invalid --from value '%s': %v\n", fromText, err)
+			os.Exit(1)
+		}
+		fromTime = parsedTime
+	}
+	var untilTime time.Time
+	if untilText != "" {
+		parsedTime, err := parsePreviewTime(untilText, location)
+		if err != nil {
+			fmt.Printf("Error: Invalid --until value '%s': %v\n", untilText, err)
+			os.Exit(1)
+		}
+		untilTime = parsedTime
+		if _, err := time.Parse("2006-01-02", untilText); err == nil {
+			// A bare date includes the whole day.
+			untilTime = parsedTime.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	var taskInfos []TaskInfo
+	if taskSelector == "all" {
+		taskInfos = GetAllTaskInfo()
+		sort.Slice(taskInfos, func(first, second int) bool {
+			return taskInfos[first].ID < taskInfos[second].ID
+		})
+	} else {
+		taskInfo, err := GetTaskInfo(taskSelector)
+		if err != nil {
+			fmt.Printf("Error: Task '%s' is not registered: %v\n", taskSelector, err)
+			fmt.Println("Run with --list to see available tasks.")
+			os.Exit(1)
+		}
+		taskInfos = []TaskInfo{taskInfo}
+	}
+	if len(taskInfos) == 0 {
+		fmt.Println("No tasks are currently registered in the scheduler.")
+		return
+	}
+
+	for index, taskInfo := range taskInfos {
+		if index > 0 {
+			fmt.Println("")
+		}
+		if taskInfo.Schedule == nil {
+			fmt.Printf("%s: no schedule defined\n", taskInfo.ID)
+			continue
+		}
+		fmt.Printf("%s: %s\n", taskInfo.ID, taskInfo.Schedule.Description())
+		runTimes := PreviewBetween(taskInfo.Schedule, fromTime, untilTime, count)
+		if len(runTimes) == 0 {
+			fmt.Println("  No upcoming runs in the requested range.")
+			continue
+		}
+		for runIndex, runTime := range runTimes {
+			fmt.Printf("  %3d. %s\n", runIndex+1, runTime.In(location).Format("Mon, 2006-01-02 15:04:05 MST"))
+		}
+		if len(runTimes) < count && untilTime.IsZero() {
+			fmt.Println("  (schedule has no further runs)")
+		}
+	}
+}
+
+// parsePreviewTime parses a CLI date ("2026-03-01") or RFC 3339 time; dates are read in location.
+func parsePreviewTime(text string, location *time.Location) (time.Time, error) {
+	if parsedTime, err := time.Parse(time.RFC3339, text); err == nil {
+		return parsedTime, nil
+	}
+	return time.ParseInLocation("2006-01-02", text, location)
+}
+
 func createTaskFromInfo(taskInfo TaskInfo) (Task, error) {
 	factory, exists := GetTaskFactory(taskInfo.ID)
 	if !exists {
@@ -241,40 +357,88 @@ func createTaskFromInfo(taskInfo TaskInfo) (Task, error) {
 	return factory(taskInfo)
 }
 
-func formatNextRunTime(nextRunPtr *time.Time) string {
+// openCompletionStore opens the file given with --completions, or returns a memory store.
+func openCompletionStore(path string) CompletionStore {
+	if path == "" {
+		return NewMemoryCompletionStore()
+	}
+	fileStore, err := NewFileCompletionStore(path)
+	if err != nil {
+		fmt.Printf("Error: Cannot open completion file '%s': %v\n", path, err)
+		os.Exit(1)
+	}
+	return fileStore
+}
+
+// pendingRunTime returns when the scheduler would run the task next, or nil if never. Like the
+// scheduler, it reads one-time runs from the completion store: an overdue run that has not
+// completed is due at currentTime.
+func pendingRunTime(taskInfo TaskInfo, completionStore CompletionStore, currentTime time.Time) *time.Time {
+	oneShot, isOneShot := taskInfo.Schedule.(OneShotSchedule)
+	if !isOneShot {
+		nextRunPtr := taskInfo.Schedule.NextRun(currentTime)
+		if nextRunPtr == nil || !nextRunPtr.After(currentTime) {
+			return nil
+		}
+		return nextRunPtr
+	}
+	runTime := oneShot.RunTime()
+	completed, err := completionStore.IsCompleted(taskInfo.ID, runTime)
+	if err != nil {
+		slog.Error("Failed to read task completion", "task_id", taskInfo.ID, "scheduled_at", runTime, "error", err)
+	}
+	if completed {
+		return nil
+	}
+	if runTime.Before(currentTime) {
+		return &currentTime
+	}
+	return &runTime
+}
+
+func formatNextRunTime(nextRunPtr *time.Time, clock Clock) string {
 	if nextRunPtr == nil {
 		return "Unknown"
 	}
 	nextRun := *nextRunPtr
-	currentTime := time.Now()
+	currentTime := clock.Now().In(nextRun.Location())
+	zoneSuffix := ""
+	if nextRun.Location() != time.Local {
+		zoneSuffix = " " + nextRun.Format("MST")
+	}
 	if utils.IsSameDay(nextRun, currentTime) {
-		return fmt.Sprintf("Today at %02d:%02d", nextRun.Hour(), nextRun.Minute())
+		return fmt.Sprintf("Today at %02d:%02d", nextRun.Hour(), nextRun.Minute()) + zoneSuffix
 	}
 	tomorrow := currentTime.Add(24 * time.Hour)
 	if utils.IsSameDay(nextRun, tomorrow) {
-		return fmt.Sprintf("Tomorrow at %02d:%02d", nextRun.Hour(), nextRun.Minute())
+		return fmt.Sprintf("Tomorrow at %02d:%02d", nextRun.Hour(), nextRun.Minute()) + zoneSuffix
 	}
-	return nextRun.Format("Mon, Jan 2 at 15:04")
+	return nextRun.Format("Mon, Jan 2 at 15:04") + zoneSuffix
 }
 
-func showHelp() {
+func showHelp(clock Clock, completionStore CompletionStore) {
 	fmt.Println("Scheduler CLI Help")
 	fmt.Println("------------------")
 	fmt.Println("--list              List all registered tasks with their schedules")
 	fmt.Println("--run <task_id>     Run a specific task immediately")
 	fmt.Println("--start             Start the scheduler with all registered tasks")
+	fmt.Println("--next <task_id>    Preview upcoming runs of a task (\"all\" for every task)")
+	fmt.Println("  -n <count>        Number of runs to show (default 10)")
+	fmt.Println("  --tz <zone>       Show times in this time zone")
+	fmt.Println("  --from <date>     Start of the range (YYYY-MM-DD or RFC 3339)")
+	fmt.Println("  --until <date>    End of the range (YYYY-MM-DD or RFC 3339)")
+	fmt.Println("--completions <file> Record completed one-time runs in this file")
 	fmt.Println("--help              Show this help message")
 	fmt.Println("")
 	fmt.Println("Available Tasks:")
 	taskInfos := GetAllTaskInfo()
-	currentTime := time.Now()
+	currentTime := clock.Now()
 	for _, taskInfo := range taskInfos {
 		scheduleStatus := "Valid schedule"
 		if taskInfo.Schedule == nil {
 			scheduleStatus = "No schedule defined"
 		} else {
-			nextRunPtr := taskInfo.Schedule.NextRun(currentTime)
-			if nextRunPtr == nil || !nextRunPtr.After(currentTime) {
+			if pendingRunTime(taskInfo, completionStore, currentTime) == nil {
 				scheduleStatus = "Invalid schedule (no future run time)"
 			}
 		}
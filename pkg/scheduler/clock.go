@@ -0,0 +1,57 @@
+package scheduler
+
+import "time"
+
+// Clock is the scheduler's source of time. The scheduler and the CLI read the time and wait on
+// timers through a Clock, so tests can substitute a FakeClock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(duration time.Duration) Timer
+	After(duration time.Duration) <-chan time.Time
+	Sleep(duration time.Duration)
+}
+
+// Timer is a single-use timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing and reports whether it was still pending.
+	Stop() bool
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+// SystemClock returns the Clock backed by the system time.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(duration time.Duration) Timer {
+	return systemTimer{timer: time.NewTimer(duration)}
+}
+
+func (systemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+func (systemClock) Sleep(duration time.Duration) {
+	time.Sleep(duration)
+}
+
+// systemTimer adapts time.Timer to Timer.
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (system systemTimer) C() <-chan time.Time {
+	return system.timer.C
+}
+
+func (system systemTimer) Stop() bool {
+	return system.timer.Stop()
+}
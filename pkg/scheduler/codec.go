@@ -0,0 +1,282 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// scheduleCodec converts one concrete schedule type to and from the fields of its JSON object.
+type scheduleCodec struct {
+	typeName string
+	encode   func(schedule TimeSchedule) (interface{}, error)
+	decode   func(data []byte) (TimeSchedule, error)
+}
+
+var (
+	scheduleCodecsByName = make(map[string]scheduleCodec)
+	scheduleCodecsByType = make(map[reflect.Type]scheduleCodec)
+	scheduleCodecsLock   = sync.RWMutex{}
+)
+
+// RegisterScheduleType makes schedules of the same concrete type as prototype serializable under
+// typeName. Their fields are encoded with encoding/json, so the type may implement json.Marshaler
+// and json.Unmarshaler to control its representation. If the decoded schedule implements
+// ScheduleValidator, it is validated after unmarshaling.
+func RegisterScheduleType(typeName string, prototype TimeSchedule) error {
+	goType := reflect.TypeOf(prototype)
+	return registerScheduleCodec(goType, scheduleCodec{
+		typeName: typeName,
+		encode: func(schedule TimeSchedule) (interface{}, error) {
+			return schedule, nil
+		},
+		decode: func(data []byte) (TimeSchedule, error) {
+			if goType.Kind() == reflect.Pointer {
+				value := reflect.New(goType.Elem())
+				if err := json.Unmarshal(data, value.Interface()); err != nil {
+					return nil, err
+				}
+				return value.Interface().(TimeSchedule), nil
+			}
+			value := reflect.New(goType)
+			if err := json.Unmarshal(data, value.Interface()); err != nil {
+				return nil, err
+			}
+			return value.Elem().Interface().(TimeSchedule), nil
+		},
+	})
+}
+
+// registerScheduleCodec adds a codec to the schedule type registry.
+func registerScheduleCodec(goType reflect.Type, codec scheduleCodec) error {
+	scheduleCodecsLock.Lock()
+	defer scheduleCodecsLock.Unlock()
+
+	if codec.typeName == "" || codec.typeName == "type" {
+		return fmt.Errorf("%w: invalid type name %q", ErrScheduleTypeExists, codec.typeName)
+	}
+	if _, exists := scheduleCodecsByName[codec.typeName]; exists {
+		return fmt.Errorf("%w: %q", ErrScheduleTypeExists, codec.typeName)
+	}
+	if existingCodec, exists := scheduleCodecsByType[goType]; exists {
+		return fmt.Errorf("%w: %s is already registered as %q", ErrScheduleTypeExists, goType, existingCodec.typeName)
+	}
+	scheduleCodecsByName[codec.typeName] = codec
+	scheduleCodecsByType[goType] = codec
+	return nil
+}
+
+// MarshalScheduleJSON encodes a schedule as a JSON object whose "type" field names its registered type.
+func MarshalScheduleJSON(schedule TimeSchedule) ([]byte, error) {
+	if schedule == nil {
+		return nil, fmt.Errorf("%w: schedule is nil", ErrUnknownScheduleType)
+	}
+	scheduleCodecsLock.RLock()
+	codec, exists := scheduleCodecsByType[reflect.TypeOf(schedule)]
+	scheduleCodecsLock.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %T is not registered", ErrUnknownScheduleType, schedule)
+	}
+
+	document, err := codec.encode(schedule)
+	if err != nil {
+		return nil, err
+	}
+	fieldsData, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	fieldsData = bytes.TrimSpace(fieldsData)
+	if len(fieldsData) < 2 || fieldsData[0] != '{' {
+		return nil, fmt.Errorf("%w: %s does not encode to a JSON object", ErrInvalidScheduleData, codec.typeName)
+	}
+	typeData, _ := json.Marshal(codec.typeName)
+	// Put the discriminator first so documents read naturally.
+	encoded := append([]byte(`{"type":`), typeData...)
+	if !bytes.Equal(fieldsData, []byte("{}")) {
+		encoded = append(encoded, ',')
+	}
+	return append(encoded, fieldsData[1:]...), nil
+}
+
+// UnmarshalScheduleJSON decodes a schedule produced by MarshalScheduleJSON. It returns
+// ErrUnknownScheduleType for unregistered types and ErrInvalidScheduleData for malformed
+// or out-of-range fields.
+func UnmarshalScheduleJSON(data []byte) (TimeSchedule, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScheduleData, err)
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("%w: expected a JSON object", ErrInvalidScheduleData)
+	}
+	var typeName string
+	if err := json.Unmarshal(fields["type"], &typeName); err != nil || typeName == "" {
+		return nil, fmt.Errorf("%w: missing \"type\" field", ErrInvalidScheduleData)
+	}
+	scheduleCodecsLock.RLock()
+	codec, exists := scheduleCodecsByName[typeName]
+	scheduleCodecsLock.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScheduleType, typeName)
+	}
+
+	delete(fields, "type")
+	fieldsData, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := codec.decode(fieldsData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidScheduleData, typeName, err)
+	}
+	if err := ValidateSchedule(schedule); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidScheduleData, typeName, err)
+	}
+	return schedule, nil
+}
+
+// ScheduleValue wraps a TimeSchedule so that it can be embedded in JSON or YAML configuration.
+// It implements json.Marshaler and json.Unmarshaler, and the MarshalYAML/UnmarshalYAML methods
+// understood by gopkg.in/yaml.v2 and gopkg.in/yaml.v3.
+type ScheduleValue struct {
+	Schedule TimeSchedule
+}
+
+// MarshalJSON encodes the wrapped schedule with MarshalScheduleJSON.
+func (value ScheduleValue) MarshalJSON() ([]byte, error) {
+	if value.Schedule == nil {
+		return []byte("null"), nil
+	}
+	return MarshalScheduleJSON(value.Schedule)
+}
+
+// UnmarshalJSON decodes the wrapped schedule with UnmarshalScheduleJSON.
+func (value *ScheduleValue) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		value.Schedule = nil
+		return nil
+	}
+	schedule, err := UnmarshalScheduleJSON(data)
+	if err != nil {
+		return err
+	}
+	value.Schedule = schedule
+	return nil
+}
+
+// MarshalYAML returns the schedule as generic maps and slices for a YAML encoder.
+func (value ScheduleValue) MarshalYAML() (interface{}, error) {
+	if value.Schedule == nil {
+		return nil, nil
+	}
+	data, err := MarshalScheduleJSON(value.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// UnmarshalYAML decodes the schedule from the generic value produced by a YAML decoder.
+func (value *ScheduleValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var document interface{}
+	if err := unmarshal(&document); err != nil {
+		return err
+	}
+	if document == nil {
+		value.Schedule = nil
+		return nil
+	}
+	data, err := json.Marshal(normalizeYAMLValue(document))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScheduleData, err)
+	}
+	return value.UnmarshalJSON(data)
+}
+
+// MarshalJSON encodes the task metadata together with its schedule.
+func (taskInfo TaskInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(taskInfoDocument{
+		ID:          taskInfo.ID,
+		Description: taskInfo.Description,
+		Schedule:    ScheduleValue{Schedule: taskInfo.Schedule},
+	})
+}
+
+// UnmarshalJSON decodes task metadata produced by MarshalJSON.
+func (taskInfo *TaskInfo) UnmarshalJSON(data []byte) error {
+	var document taskInfoDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	*taskInfo = TaskInfo{ID: document.ID, Description: document.Description, Schedule: document.Schedule.Schedule}
+	return nil
+}
+
+// taskInfoDocument is the serialized form of TaskInfo.
+type taskInfoDocument struct {
+	ID          string        `json:"id"`
+	Description string        `json:"description,omitempty"`
+	Schedule    ScheduleValue `json:"schedule"`
+}
+
+// normalizeYAMLValue converts the map[interface{}]interface{} values produced by yaml.v2 into
+// map[string]interface{} so that they can be encoded as JSON.
+func normalizeYAMLValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		normalizedMap := make(map[string]interface{}, len(typedValue))
+		for key, element := range typedValue {
+			normalizedMap[fmt.Sprint(key)] = normalizeYAMLValue(element)
+		}
+		return normalizedMap
+	case map[string]interface{}:
+		normalizedMap := make(map[string]interface{}, len(typedValue))
+		for key, element := range typedValue {
+			normalizedMap[key] = normalizeYAMLValue(element)
+		}
+		return normalizedMap
+	case []interface{}:
+		normalizedSlice := make([]interface{}, len(typedValue))
+		for index, element := range typedValue {
+			normalizedSlice[index] = normalizeYAMLValue(element)
+		}
+		return normalizedSlice
+	default:
+		return value
+	}
+}
+
+// decodeStrictJSON decodes data into target, rejecting unknown fields.
+func decodeStrictJSON(data []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+// encodeLocation returns the IANA name of location, or "" when it is nil.
+func encodeLocation(location *time.Location) string {
+	if location == nil {
+		return ""
+	}
+	return location.String()
+}
+
+// decodeLocation loads a location by IANA name; "" yields nil.
+func decodeLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return location, nil
+}
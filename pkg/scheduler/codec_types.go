@@ -0,0 +1,953 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func init() {
+	builtinCodecs := []struct {
+		prototype TimeSchedule
+		codec     scheduleCodec
+	}{
+		{DailySchedule{}, scheduleCodec{typeName: "daily", encode: encodeDailySchedule, decode: decodeDailySchedule}},
+		{WeekdaySchedule{}, scheduleCodec{typeName: "weekday", encode: encodeWeekdaySchedule, decode: decodeWeekdaySchedule}},
+		{MonthlySchedule{}, scheduleCodec{typeName: "monthly", encode: encodeMonthlySchedule, decode: decodeMonthlySchedule}},
+		{IntervalSchedule{}, scheduleCodec{typeName: "interval", encode: encodeIntervalSchedule, decode: decodeIntervalSchedule}},
+		{&OneTimeSchedule{}, scheduleCodec{typeName: "once", encode: encodeOneTimeSchedule, decode: decodeOneTimeSchedule}},
+		{CronSchedule{}, scheduleCodec{typeName: "cron", encode: encodeCronSchedule, decode: decodeCronSchedule}},
+		{RRuleSchedule{}, scheduleCodec{typeName: "rrule", encode: encodeRRuleSchedule, decode: decodeRRuleSchedule}},
+		{BoundedSchedule{}, scheduleCodec{typeName: "bounded", encode: encodeBoundedSchedule, decode: decodeBoundedSchedule}},
+		{JitterSchedule{}, scheduleCodec{typeName: "jitter", encode: encodeJitterSchedule, decode: decodeJitterSchedule}},
+		{AnyOfSchedule{}, scheduleCodec{typeName: "any_of", encode: encodeAnyOfSchedule, decode: decodeAnyOfSchedule}},
+		{AllOfSchedule{}, scheduleCodec{typeName: "all_of", encode: encodeAllOfSchedule, decode: decodeAllOfSchedule}},
+		{SolarSchedule{}, scheduleCodec{typeName: "solar", encode: encodeSolarSchedule, decode: decodeSolarSchedule}},
+		{OnCalendarSchedule{}, scheduleCodec{typeName: "on_calendar", encode: encodeOnCalendarSchedule, decode: decodeOnCalendarSchedule}},
+		{ISOWeekSchedule{}, scheduleCodec{typeName: "iso_week", encode: encodeISOWeekSchedule, decode: decodeISOWeekSchedule}},
+		{FiscalSchedule{}, scheduleCodec{typeName: "fiscal", encode: encodeFiscalSchedule, decode: decodeFiscalSchedule}},
+		{WithinSchedule{}, scheduleCodec{typeName: "within", encode: encodeWithinSchedule, decode: decodeWithinSchedule}},
+		{ExceptSchedule{}, scheduleCodec{typeName: "except", encode: encodeExceptSchedule, decode: decodeExceptSchedule}},
+		{BusinessDaySchedule{}, scheduleCodec{typeName: "business_day", encode: encodeBusinessDaySchedule, decode: decodeBusinessDaySchedule}},
+		{RandomWindowSchedule{}, scheduleCodec{typeName: "random_window", encode: encodeRandomWindowSchedule, decode: decodeRandomWindowSchedule}},
+	}
+	for _, builtinCodec := range builtinCodecs {
+		if err := registerScheduleCodec(reflect.TypeOf(builtinCodec.prototype), builtinCodec.codec); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// dstDocument is the serialized form of DSTPolicy.
+type dstDocument struct {
+	Gap     string `json:"gap,omitempty"`
+	Overlap string `json:"overlap,omitempty"`
+}
+
+var (
+	dstGapNames     = map[DSTGapPolicy]string{DSTGapRunAtNextValidTime: "run_at_next_valid_time", DSTGapSkip: "skip"}
+	dstOverlapNames = map[DSTOverlapPolicy]string{DSTOverlapRunOnce: "run_once", DSTOverlapRunBoth: "run_both"}
+)
+
+func encodeDSTPolicy(policy DSTPolicy) *dstDocument {
+	if policy == (DSTPolicy{}) {
+		return nil
+	}
+	return &dstDocument{Gap: dstGapNames[policy.Gap], Overlap: dstOverlapNames[policy.Overlap]}
+}
+
+func decodeDSTPolicy(document *dstDocument) (DSTPolicy, error) {
+	var policy DSTPolicy
+	if document == nil {
+		return policy, nil
+	}
+	if document.Gap != "" {
+		gap, found := findPolicyName(dstGapNames, document.Gap)
+		if !found {
+			return policy, fmt.Errorf("unknown DST gap policy %q", document.Gap)
+		}
+		policy.Gap = gap
+	}
+	if document.Overlap != "" {
+		overlap, found := findPolicyName(dstOverlapNames, document.Overlap)
+		if !found {
+			return policy, fmt.Errorf("unknown DST overlap policy %q", document.Overlap)
+		}
+		policy.Overlap = overlap
+	}
+	return policy, nil
+}
+
+// findPolicyName looks up the policy value serialized as name.
+func findPolicyName[Policy comparable](names map[Policy]string, name string) (Policy, bool) {
+	for policy, policyName := range names {
+		if policyName == name {
+			return policy, true
+		}
+	}
+	var zero Policy
+	return zero, false
+}
+
+type dailyDocument struct {
+	Hour     int          `json:"hour"`
+	Minute   int          `json:"minute"`
+	Location string       `json:"location,omitempty"`
+	DST      *dstDocument `json:"dst,omitempty"`
+}
+
+func encodeDailySchedule(schedule TimeSchedule) (interface{}, error) {
+	daily := schedule.(DailySchedule)
+	return dailyDocument{Hour: daily.Hour, Minute: daily.Minute, Location: encodeLocation(daily.Location), DST: encodeDSTPolicy(daily.DST)}, nil
+}
+
+func decodeDailySchedule(data []byte) (TimeSchedule, error) {
+	var document dailyDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	location, err := decodeLocation(document.Location)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := decodeDSTPolicy(document.DST)
+	if err != nil {
+		return nil, err
+	}
+	return DailySchedule{Hour: document.Hour, Minute: document.Minute, Location: location, DST: policy}, nil
+}
+
+type weekdayDocument struct {
+	Weekdays []string     `json:"weekdays"`
+	Hour     int          `json:"hour"`
+	Minute   int          `json:"minute"`
+	Location string       `json:"location,omitempty"`
+	DST      *dstDocument `json:"dst,omitempty"`
+}
+
+func encodeWeekdaySchedule(schedule TimeSchedule) (interface{}, error) {
+	weekday := schedule.(WeekdaySchedule)
+	weekdayNames := make([]string, len(weekday.Weekdays))
+	for index, day := range weekday.Weekdays {
+		weekdayNames[index] = strings.ToLower(day.String())
+	}
+	return weekdayDocument{
+		Weekdays: weekdayNames, Hour: weekday.Hour, Minute: weekday.Minute,
+		Location: encodeLocation(weekday.Location), DST: encodeDSTPolicy(weekday.DST),
+	}, nil
+}
+
+func decodeWeekdaySchedule(data []byte) (TimeSchedule, error) {
+	var document weekdayDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	weekdays := make([]time.Weekday, len(document.Weekdays))
+	for index, weekdayName := range document.Weekdays {
+		day, exists := scheduleWeekdayNames[strings.ToLower(weekdayName)]
+		if !exists {
+			return nil, fmt.Errorf("unknown weekday %q", weekdayName)
+		}
+		weekdays[index] = day
+	}
+	location, err := decodeLocation(document.Location)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := decodeDSTPolicy(document.DST)
+	if err != nil {
+		return nil, err
+	}
+	return WeekdaySchedule{Weekdays: weekdays, Hour: document.Hour, Minute: document.Minute, Location: location, DST: policy}, nil
+}
+
+type nthWeekdayDocument struct {
+	Weekday string `json:"weekday"`
+	N       int    `json:"n"`
+}
+
+type monthlyDocument struct {
+	Days        []int                `json:"days,omitempty"`
+	NthWeekdays []nthWeekdayDocument `json:"nth_weekdays,omitempty"`
+	ShortMonths string               `json:"short_months,omitempty"`
+	Hour        int                  `json:"hour"`
+	Minute      int                  `json:"minute"`
+	Location    string               `json:"location,omitempty"`
+	DST         *dstDocument         `json:"dst,omitempty"`
+}
+
+var shortMonthNames = map[ShortMonthPolicy]string{ShortMonthSkip: "skip", ShortMonthClamp: "clamp"}
+
+func encodeMonthlySchedule(schedule TimeSchedule) (interface{}, error) {
+	monthly := schedule.(MonthlySchedule)
+	document := monthlyDocument{
+		Days: monthly.Days, Hour: monthly.Hour, Minute: monthly.Minute,
+		Location: encodeLocation(monthly.Location), DST: encodeDSTPolicy(monthly.DST),
+	}
+	if monthly.ShortMonths != ShortMonthSkip {
+		document.ShortMonths = shortMonthNames[monthly.ShortMonths]
+	}
+	for _, nthWeekday := range monthly.NthWeekdays {
+		document.NthWeekdays = append(document.NthWeekdays, nthWeekdayDocument{Weekday: strings.ToLower(nthWeekday.Weekday.String()), N: nthWeekday.N})
+	}
+	return document, nil
+}
+
+func decodeMonthlySchedule(data []byte) (TimeSchedule, error) {
+	var document monthlyDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	monthly := MonthlySchedule{Days: document.Days, Hour: document.Hour, Minute: document.Minute}
+	for _, nthWeekdayDocument := range document.NthWeekdays {
+		day, exists := scheduleWeekdayNames[strings.ToLower(nthWeekdayDocument.Weekday)]
+		if !exists {
+			return nil, fmt.Errorf("unknown weekday %q", nthWeekdayDocument.Weekday)
+		}
+		monthly.NthWeekdays = append(monthly.NthWeekdays, NthWeekday{Weekday: day, N: nthWeekdayDocument.N})
+	}
+	if document.ShortMonths != "" {
+		shortMonths, found := findPolicyName(shortMonthNames, document.ShortMonths)
+		if !found {
+			return nil, fmt.Errorf("unknown short month policy %q", document.ShortMonths)
+		}
+		monthly.ShortMonths = shortMonths
+	}
+	var err error
+	if monthly.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	if monthly.DST, err = decodeDSTPolicy(document.DST); err != nil {
+		return nil, err
+	}
+	return monthly, nil
+}
+
+type intervalDocument struct {
+	Interval  string       `json:"interval"`
+	StartTime *time.Time   `json:"start_time,omitempty"`
+	Aligned   bool         `json:"aligned,omitempty"`
+	Location  string       `json:"location,omitempty"`
+	DST       *dstDocument `json:"dst,omitempty"`
+}
+
+func encodeIntervalSchedule(schedule TimeSchedule) (interface{}, error) {
+	interval := schedule.(IntervalSchedule)
+	document := intervalDocument{
+		Interval: interval.Interval.String(), Aligned: interval.Aligned,
+		Location: encodeLocation(interval.Location), DST: encodeDSTPolicy(interval.DST),
+	}
+	if !interval.StartTime.IsZero() {
+		document.StartTime = &interval.StartTime
+	}
+	return document, nil
+}
+
+func decodeIntervalSchedule(data []byte) (TimeSchedule, error) {
+	var document intervalDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	interval, err := parseDurationField("interval", document.Interval)
+	if err != nil {
+		return nil, err
+	}
+	schedule := IntervalSchedule{Interval: interval, Aligned: document.Aligned}
+	if document.StartTime != nil {
+		schedule.StartTime = *document.StartTime
+	}
+	if schedule.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	if schedule.DST, err = decodeDSTPolicy(document.DST); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+type oneTimeDocument struct {
+	RunTime time.Time `json:"run_time"`
+}
+
+func encodeOneTimeSchedule(schedule TimeSchedule) (interface{}, error) {
+	return oneTimeDocument{RunTime: schedule.(*OneTimeSchedule).RunTime()}, nil
+}
+
+func decodeOneTimeSchedule(data []byte) (TimeSchedule, error) {
+	var document oneTimeDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	if document.RunTime.IsZero() {
+		return nil, errors.New("run_time is required")
+	}
+	return NewOneTimeSchedule(document.RunTime), nil
+}
+
+type cronDocument struct {
+	Expression string       `json:"expression"`
+	Location   string       `json:"location,omitempty"`
+	DST        *dstDocument `json:"dst,omitempty"`
+}
+
+func encodeCronSchedule(schedule TimeSchedule) (interface{}, error) {
+	cron := schedule.(CronSchedule)
+	return cronDocument{Expression: cron.Expression(), Location: encodeLocation(cron.Location), DST: encodeDSTPolicy(cron.DST)}, nil
+}
+
+func decodeCronSchedule(data []byte) (TimeSchedule, error) {
+	var document cronDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	cron, err := NewCronSchedule(document.Expression)
+	if err != nil {
+		return nil, err
+	}
+	if document.Location != "" {
+		if cron.Location, err = decodeLocation(document.Location); err != nil {
+			return nil, err
+		}
+	}
+	if cron.DST, err = decodeDSTPolicy(document.DST); err != nil {
+		return nil, err
+	}
+	return cron, nil
+}
+
+func encodeOnCalendarSchedule(schedule TimeSchedule) (interface{}, error) {
+	onCalendar := schedule.(OnCalendarSchedule)
+	return cronDocument{Expression: onCalendar.Expression(), Location: encodeLocation(onCalendar.Location), DST: encodeDSTPolicy(onCalendar.DST)}, nil
+}
+
+func decodeOnCalendarSchedule(data []byte) (TimeSchedule, error) {
+	var document cronDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	onCalendar, err := NewOnCalendarSchedule(document.Expression)
+	if err != nil {
+		return nil, err
+	}
+	if document.Location != "" {
+		if onCalendar.Location, err = decodeLocation(document.Location); err != nil {
+			return nil, err
+		}
+	}
+	if onCalendar.DST, err = decodeDSTPolicy(document.DST); err != nil {
+		return nil, err
+	}
+	return onCalendar, nil
+}
+
+type rruleDocument struct {
+	Rule     string      `json:"rule"`
+	Start    time.Time   `json:"start"`
+	Location string      `json:"location,omitempty"`
+	ExDates  []time.Time `json:"ex_dates,omitempty"`
+	RDates   []time.Time `json:"r_dates,omitempty"`
+}
+
+func encodeRRuleSchedule(schedule TimeSchedule) (interface{}, error) {
+	rrule := schedule.(RRuleSchedule)
+	return rruleDocument{
+		Rule: rrule.Rule(), Start: rrule.Start, Location: encodeLocation(rrule.Start.Location()),
+		ExDates: rrule.ExDates, RDates: rrule.RDates,
+	}, nil
+}
+
+func decodeRRuleSchedule(data []byte) (TimeSchedule, error) {
+	var document rruleDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	if document.Start.IsZero() {
+		return nil, errors.New("start is required")
+	}
+	start := document.Start
+	if document.Location != "" {
+		location, err := decodeLocation(document.Location)
+		if err != nil {
+			return nil, err
+		}
+		start = start.In(location)
+	}
+	rrule, err := NewRRuleSchedule(document.Rule, start)
+	if err != nil {
+		return nil, err
+	}
+	rrule.ExDates = document.ExDates
+	rrule.RDates = document.RDates
+	return rrule, nil
+}
+
+type boundedDocument struct {
+	Schedule  ScheduleValue `json:"schedule"`
+	NotBefore *time.Time    `json:"not_before,omitempty"`
+	NotAfter  *time.Time    `json:"not_after,omitempty"`
+	MaxRuns   int           `json:"max_runs,omitempty"`
+}
+
+func encodeBoundedSchedule(schedule TimeSchedule) (interface{}, error) {
+	bounded := schedule.(BoundedSchedule)
+	document := boundedDocument{Schedule: ScheduleValue{Schedule: bounded.Schedule}, MaxRuns: bounded.MaxRuns}
+	if !bounded.NotBefore.IsZero() {
+		document.NotBefore = &bounded.NotBefore
+	}
+	if !bounded.NotAfter.IsZero() {
+		document.NotAfter = &bounded.NotAfter
+	}
+	return document, nil
+}
+
+func decodeBoundedSchedule(data []byte) (TimeSchedule, error) {
+	var document boundedDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	bounded := BoundedSchedule{Schedule: document.Schedule.Schedule, MaxRuns: document.MaxRuns}
+	if document.NotBefore != nil {
+		bounded.NotBefore = *document.NotBefore
+	}
+	if document.NotAfter != nil {
+		bounded.NotAfter = *document.NotAfter
+	}
+	return bounded, nil
+}
+
+type jitterDocument struct {
+	Schedule  ScheduleValue `json:"schedule"`
+	MaxJitter string        `json:"max_jitter"`
+	Seed      string        `json:"seed,omitempty"`
+}
+
+func encodeJitterSchedule(schedule TimeSchedule) (interface{}, error) {
+	jitter := schedule.(JitterSchedule)
+	return jitterDocument{Schedule: ScheduleValue{Schedule: jitter.Schedule}, MaxJitter: jitter.MaxJitter.String(), Seed: jitter.Seed}, nil
+}
+
+func decodeJitterSchedule(data []byte) (TimeSchedule, error) {
+	var document jitterDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	maxJitter, err := parseDurationField("max_jitter", document.MaxJitter)
+	if err != nil {
+		return nil, err
+	}
+	return JitterSchedule{Schedule: document.Schedule.Schedule, MaxJitter: maxJitter, Seed: document.Seed}, nil
+}
+
+type schedulesDocument struct {
+	Schedules []ScheduleValue `json:"schedules"`
+}
+
+func encodeAnyOfSchedule(schedule TimeSchedule) (interface{}, error) {
+	return encodeSchedules(schedule.(AnyOfSchedule).Schedules), nil
+}
+
+func decodeAnyOfSchedule(data []byte) (TimeSchedule, error) {
+	schedules, err := decodeSchedules(data)
+	if err != nil {
+		return nil, err
+	}
+	return AnyOfSchedule{Schedules: schedules}, nil
+}
+
+func encodeAllOfSchedule(schedule TimeSchedule) (interface{}, error) {
+	return encodeSchedules(schedule.(AllOfSchedule).Schedules), nil
+}
+
+func decodeAllOfSchedule(data []byte) (TimeSchedule, error) {
+	schedules, err := decodeSchedules(data)
+	if err != nil {
+		return nil, err
+	}
+	return AllOfSchedule{Schedules: schedules}, nil
+}
+
+func encodeSchedules(schedules []TimeSchedule) schedulesDocument {
+	document := schedulesDocument{Schedules: make([]ScheduleValue, len(schedules))}
+	for index, schedule := range schedules {
+		document.Schedules[index] = ScheduleValue{Schedule: schedule}
+	}
+	return document
+}
+
+func decodeSchedules(data []byte) ([]TimeSchedule, error) {
+	var document schedulesDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	schedules := make([]TimeSchedule, len(document.Schedules))
+	for index, value := range document.Schedules {
+		schedules[index] = value.Schedule
+	}
+	return schedules, nil
+}
+
+type solarDocument struct {
+	Event     string  `json:"event"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Offset    string  `json:"offset,omitempty"`
+	Polar     string  `json:"polar,omitempty"`
+	Location  string  `json:"location,omitempty"`
+}
+
+var (
+	solarEventCodecNames = map[SolarEvent]string{Sunrise: "sunrise", Sunset: "sunset", CivilDawn: "civil_dawn", CivilDusk: "civil_dusk"}
+	polarPolicyNames     = map[PolarPolicy]string{PolarSkip: "skip", PolarNearest: "nearest"}
+)
+
+func encodeSolarSchedule(schedule TimeSchedule) (interface{}, error) {
+	solar := schedule.(SolarSchedule)
+	document := solarDocument{
+		Event: solarEventCodecNames[solar.Event], Latitude: solar.Latitude, Longitude: solar.Longitude,
+		Location: encodeLocation(solar.Location),
+	}
+	if solar.Offset != 0 {
+		document.Offset = solar.Offset.String()
+	}
+	if solar.Polar != PolarSkip {
+		document.Polar = polarPolicyNames[solar.Polar]
+	}
+	return document, nil
+}
+
+func decodeSolarSchedule(data []byte) (TimeSchedule, error) {
+	var document solarDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	event, found := findPolicyName(solarEventCodecNames, document.Event)
+	if !found {
+		return nil, fmt.Errorf("unknown solar event %q", document.Event)
+	}
+	solar := SolarSchedule{Event: event, Latitude: document.Latitude, Longitude: document.Longitude}
+	var err error
+	if document.Offset != "" {
+		if solar.Offset, err = parseDurationField("offset", document.Offset); err != nil {
+			return nil, err
+		}
+	}
+	if document.Polar != "" {
+		if solar.Polar, found = findPolicyName(polarPolicyNames, document.Polar); !found {
+			return nil, fmt.Errorf("unknown polar policy %q", document.Polar)
+		}
+	}
+	if solar.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	return solar, nil
+}
+
+type isoWeekDocument struct {
+	Weeks    []int        `json:"weeks,omitempty"`
+	Weekdays []string     `json:"weekdays"`
+	Hour     int          `json:"hour"`
+	Minute   int          `json:"minute"`
+	Location string       `json:"location,omitempty"`
+	DST      *dstDocument `json:"dst,omitempty"`
+}
+
+func encodeISOWeekSchedule(schedule TimeSchedule) (interface{}, error) {
+	isoWeek := schedule.(ISOWeekSchedule)
+	weekdayNames := make([]string, len(isoWeek.Weekdays))
+	for index, day := range isoWeek.Weekdays {
+		weekdayNames[index] = strings.ToLower(day.String())
+	}
+	return isoWeekDocument{
+		Weeks: isoWeek.Weeks, Weekdays: weekdayNames, Hour: isoWeek.Hour, Minute: isoWeek.Minute,
+		Location: encodeLocation(isoWeek.Location), DST: encodeDSTPolicy(isoWeek.DST),
+	}, nil
+}
+
+func decodeISOWeekSchedule(data []byte) (TimeSchedule, error) {
+	var document isoWeekDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	isoWeek := ISOWeekSchedule{Weeks: document.Weeks, Hour: document.Hour, Minute: document.Minute}
+	for _, weekdayName := range document.Weekdays {
+		day, exists := scheduleWeekdayNames[strings.ToLower(weekdayName)]
+		if !exists {
+			return nil, fmt.Errorf("unknown weekday %q", weekdayName)
+		}
+		isoWeek.Weekdays = append(isoWeek.Weekdays, day)
+	}
+	var err error
+	if isoWeek.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	if isoWeek.DST, err = decodeDSTPolicy(document.DST); err != nil {
+		return nil, err
+	}
+	return isoWeek, nil
+}
+
+type fiscalDocument struct {
+	Event          string       `json:"event"`
+	StartMonth     int          `json:"start_month,omitempty"`
+	WeekPattern    []int        `json:"week_pattern,omitempty"`
+	YearEndWeekday string       `json:"year_end_weekday,omitempty"`
+	YearEndNearest bool         `json:"year_end_nearest,omitempty"`
+	Hour           int          `json:"hour"`
+	Minute         int          `json:"minute"`
+	Location       string       `json:"location,omitempty"`
+	DST            *dstDocument `json:"dst,omitempty"`
+}
+
+var fiscalEventCodecNames = map[FiscalEvent]string{
+	FiscalPeriodEnd: "period_end", FiscalPeriodStart: "period_start",
+	FiscalQuarterEnd: "quarter_end", FiscalQuarterStart: "quarter_start",
+	FiscalYearEnd: "year_end", FiscalYearStart: "year_start",
+}
+
+func encodeFiscalSchedule(schedule TimeSchedule) (interface{}, error) {
+	fiscal := schedule.(FiscalSchedule)
+	document := fiscalDocument{
+		Event: fiscalEventCodecNames[fiscal.Event], StartMonth: int(fiscal.Calendar.StartMonth),
+		WeekPattern: fiscal.Calendar.WeekPattern, YearEndNearest: fiscal.Calendar.YearEndNearest,
+		Hour: fiscal.Hour, Minute: fiscal.Minute, Location: encodeLocation(fiscal.Location), DST: encodeDSTPolicy(fiscal.DST),
+	}
+	if fiscal.Calendar.isWeekBased() {
+		document.YearEndWeekday = strings.ToLower(fiscal.Calendar.YearEndWeekday.String())
+	}
+	return document, nil
+}
+
+func decodeFiscalSchedule(data []byte) (TimeSchedule, error) {
+	var document fiscalDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	event, found := findPolicyName(fiscalEventCodecNames, document.Event)
+	if !found {
+		return nil, fmt.Errorf("unknown fiscal event %q", document.Event)
+	}
+	fiscal := FiscalSchedule{
+		Calendar: FiscalCalendar{StartMonth: time.Month(document.StartMonth), WeekPattern: document.WeekPattern, YearEndNearest: document.YearEndNearest},
+		Event:    event, Hour: document.Hour, Minute: document.Minute,
+	}
+	if document.YearEndWeekday != "" {
+		day, exists := scheduleWeekdayNames[strings.ToLower(document.YearEndWeekday)]
+		if !exists {
+			return nil, fmt.Errorf("unknown weekday %q", document.YearEndWeekday)
+		}
+		fiscal.Calendar.YearEndWeekday = day
+	}
+	var err error
+	if fiscal.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	if fiscal.DST, err = decodeDSTPolicy(document.DST); err != nil {
+		return nil, err
+	}
+	return fiscal, nil
+}
+
+// windowDocument is the serialized form of the built-in Window types. Type selects which of
+// the other fields apply.
+type windowDocument struct {
+	Type     string         `json:"type"`
+	Start    string         `json:"start,omitempty"`    // time_of_day: offset from midnight, such as "9h".
+	End      string         `json:"end,omitempty"`      // time_of_day
+	Weekdays []string       `json:"weekdays,omitempty"` // time_of_day
+	Location string         `json:"location,omitempty"` // time_of_day
+	Schedule *ScheduleValue `json:"schedule,omitempty"` // schedule
+	Duration string         `json:"duration,omitempty"` // schedule
+	From     *time.Time     `json:"from,omitempty"`     // date_range
+	Until    *time.Time     `json:"until,omitempty"`    // date_range
+}
+
+func encodeWindow(window Window) (windowDocument, error) {
+	switch typedWindow := window.(type) {
+	case TimeOfDayWindow:
+		document := windowDocument{
+			Type: "time_of_day", Start: typedWindow.Start.String(), End: typedWindow.End.String(),
+			Location: encodeLocation(typedWindow.Location),
+		}
+		for _, day := range typedWindow.Weekdays {
+			document.Weekdays = append(document.Weekdays, strings.ToLower(day.String()))
+		}
+		return document, nil
+	case ScheduleWindow:
+		return windowDocument{Type: "schedule", Schedule: &ScheduleValue{Schedule: typedWindow.Schedule}, Duration: typedWindow.Duration.String()}, nil
+	case DateRangeWindow:
+		return windowDocument{Type: "date_range", From: &typedWindow.Start, Until: &typedWindow.End}, nil
+	default:
+		return windowDocument{}, fmt.Errorf("%w: window %T is not serializable", ErrUnknownScheduleType, window)
+	}
+}
+
+func decodeWindow(document windowDocument) (Window, error) {
+	var unexpectedFields []string
+	reject := func(fieldName string, isSet bool) {
+		if isSet {
+			unexpectedFields = append(unexpectedFields, fieldName)
+		}
+	}
+	var window Window
+	var err error
+	switch document.Type {
+	case "time_of_day":
+		reject("schedule", document.Schedule != nil)
+		reject("duration", document.Duration != "")
+		reject("from", document.From != nil)
+		reject("until", document.Until != nil)
+		window, err = decodeTimeOfDayWindow(document)
+	case "schedule":
+		reject("start", document.Start != "")
+		reject("end", document.End != "")
+		reject("weekdays", document.Weekdays != nil)
+		reject("location", document.Location != "")
+		reject("from", document.From != nil)
+		reject("until", document.Until != nil)
+		if document.Schedule == nil {
+			return nil, errors.New("schedule window requires schedule")
+		}
+		var duration time.Duration
+		duration, err = parseDurationField("duration", document.Duration)
+		window = ScheduleWindow{Schedule: document.Schedule.Schedule, Duration: duration}
+	case "date_range":
+		reject("start", document.Start != "")
+		reject("end", document.End != "")
+		reject("weekdays", document.Weekdays != nil)
+		reject("location", document.Location != "")
+		reject("schedule", document.Schedule != nil)
+		reject("duration", document.Duration != "")
+		if document.From == nil || document.Until == nil {
+			return nil, errors.New("date_range window requires from and until")
+		}
+		window = DateRangeWindow{Start: *document.From, End: *document.Until}
+	default:
+		return nil, fmt.Errorf("unknown window type %q", document.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(unexpectedFields) > 0 {
+		return nil, fmt.Errorf("%s window does not take %s", document.Type, strings.Join(unexpectedFields, ", "))
+	}
+	return window, nil
+}
+
+func decodeTimeOfDayWindow(document windowDocument) (Window, error) {
+	start, err := parseDurationField("start", document.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseDurationField("end", document.End)
+	if err != nil {
+		return nil, err
+	}
+	window := TimeOfDayWindow{Start: start, End: end}
+	for _, weekdayName := range document.Weekdays {
+		day, exists := scheduleWeekdayNames[strings.ToLower(weekdayName)]
+		if !exists {
+			return nil, fmt.Errorf("unknown weekday %q", weekdayName)
+		}
+		window.Weekdays = append(window.Weekdays, day)
+	}
+	if window.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+type windowedDocument struct {
+	Schedule ScheduleValue    `json:"schedule"`
+	Windows  []windowDocument `json:"windows"`
+}
+
+func encodeWindowedSchedule(schedule TimeSchedule, windows []Window) (interface{}, error) {
+	document := windowedDocument{Schedule: ScheduleValue{Schedule: schedule}, Windows: make([]windowDocument, len(windows))}
+	for index, window := range windows {
+		windowDocument, err := encodeWindow(window)
+		if err != nil {
+			return nil, err
+		}
+		document.Windows[index] = windowDocument
+	}
+	return document, nil
+}
+
+func decodeWindowedSchedule(data []byte) (TimeSchedule, []Window, error) {
+	var document windowedDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, nil, err
+	}
+	windows := make([]Window, len(document.Windows))
+	for index, windowDocument := range document.Windows {
+		window, err := decodeWindow(windowDocument)
+		if err != nil {
+			return nil, nil, fmt.Errorf("windows[%d]: %w", index, err)
+		}
+		windows[index] = window
+	}
+	return document.Schedule.Schedule, windows, nil
+}
+
+func encodeWithinSchedule(schedule TimeSchedule) (interface{}, error) {
+	within := schedule.(WithinSchedule)
+	return encodeWindowedSchedule(within.Schedule, within.Windows)
+}
+
+func decodeWithinSchedule(data []byte) (TimeSchedule, error) {
+	schedule, windows, err := decodeWindowedSchedule(data)
+	if err != nil {
+		return nil, err
+	}
+	return WithinSchedule{Schedule: schedule, Windows: windows}, nil
+}
+
+func encodeExceptSchedule(schedule TimeSchedule) (interface{}, error) {
+	except := schedule.(ExceptSchedule)
+	return encodeWindowedSchedule(except.Schedule, except.Windows)
+}
+
+func decodeExceptSchedule(data []byte) (TimeSchedule, error) {
+	schedule, windows, err := decodeWindowedSchedule(data)
+	if err != nil {
+		return nil, err
+	}
+	return ExceptSchedule{Schedule: schedule, Windows: windows}, nil
+}
+
+type randomWindowDocument struct {
+	Window windowDocument `json:"window"`
+	Seed   string         `json:"seed,omitempty"`
+}
+
+func encodeRandomWindowSchedule(schedule TimeSchedule) (interface{}, error) {
+	random := schedule.(RandomWindowSchedule)
+	if random.Window == nil {
+		return nil, errors.New("random_window schedule has no window")
+	}
+	window, err := encodeWindow(random.Window)
+	if err != nil {
+		return nil, err
+	}
+	return randomWindowDocument{Window: window, Seed: random.Seed}, nil
+}
+
+func decodeRandomWindowSchedule(data []byte) (TimeSchedule, error) {
+	var document randomWindowDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	window, err := decodeWindow(document.Window)
+	if err != nil {
+		return nil, fmt.Errorf("window: %w", err)
+	}
+	return RandomWindowSchedule{Window: window, Seed: document.Seed}, nil
+}
+
+// holidayDocument is the serialized form of Holiday; Date is a calendar date such as "2026-12-25".
+type holidayDocument struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// calendarDocument is the serialized form of HolidayCalendar. A missing weekend means Saturday
+// and Sunday; an empty one means every day of the week is a business day.
+type calendarDocument struct {
+	Weekend       *[]string         `json:"weekend,omitempty"`
+	Holidays      []holidayDocument `json:"holidays,omitempty"`
+	ExcludedDates []string          `json:"excluded_dates,omitempty"`
+}
+
+type businessDayDocument struct {
+	Schedule ScheduleValue    `json:"schedule"`
+	Calendar calendarDocument `json:"calendar"`
+	Policy   string           `json:"policy,omitempty"`
+	Location string           `json:"location,omitempty"`
+}
+
+var businessDayPolicyNames = map[BusinessDayPolicy]string{
+	BusinessDaySkip: "skip", BusinessDayShiftForward: "shift_forward", BusinessDayShiftBackward: "shift_backward",
+}
+
+func encodeBusinessDaySchedule(schedule TimeSchedule) (interface{}, error) {
+	business := schedule.(BusinessDaySchedule)
+	calendar, isHolidayCalendar := business.Calendar.(HolidayCalendar)
+	if !isHolidayCalendar {
+		return nil, fmt.Errorf("%w: calendar %T is not serializable", ErrUnknownScheduleType, business.Calendar)
+	}
+	document := businessDayDocument{Schedule: ScheduleValue{Schedule: business.Schedule}, Location: encodeLocation(business.Location)}
+	if business.Policy != BusinessDaySkip {
+		document.Policy = businessDayPolicyNames[business.Policy]
+	}
+	if calendar.Weekend != nil {
+		weekendNames := make([]string, len(calendar.Weekend))
+		for index, day := range calendar.Weekend {
+			weekendNames[index] = strings.ToLower(day.String())
+		}
+		document.Calendar.Weekend = &weekendNames
+	}
+	for _, holiday := range calendar.Holidays {
+		document.Calendar.Holidays = append(document.Calendar.Holidays, holidayDocument{Date: holiday.Date.Format(time.DateOnly), Name: holiday.Name})
+	}
+	for _, excludedDate := range calendar.ExcludedDates {
+		document.Calendar.ExcludedDates = append(document.Calendar.ExcludedDates, excludedDate.Format(time.DateOnly))
+	}
+	return document, nil
+}
+
+func decodeBusinessDaySchedule(data []byte) (TimeSchedule, error) {
+	var document businessDayDocument
+	if err := decodeStrictJSON(data, &document); err != nil {
+		return nil, err
+	}
+	business := BusinessDaySchedule{Schedule: document.Schedule.Schedule}
+	if document.Policy != "" {
+		policy, found := findPolicyName(businessDayPolicyNames, document.Policy)
+		if !found {
+			return nil, fmt.Errorf("unknown business day policy %q", document.Policy)
+		}
+		business.Policy = policy
+	}
+	var calendar HolidayCalendar
+	if document.Calendar.Weekend != nil {
+		calendar.Weekend = []time.Weekday{}
+		for _, weekdayName := range *document.Calendar.Weekend {
+			day, exists := scheduleWeekdayNames[strings.ToLower(weekdayName)]
+			if !exists {
+				return nil, fmt.Errorf("unknown weekday %q", weekdayName)
+			}
+			calendar.Weekend = append(calendar.Weekend, day)
+		}
+	}
+	for _, holiday := range document.Calendar.Holidays {
+		date, err := time.Parse(time.DateOnly, holiday.Date)
+		if err != nil {
+			return nil, fmt.Errorf("holiday date %q is not a date", holiday.Date)
+		}
+		calendar.Holidays = append(calendar.Holidays, Holiday{Date: date, Name: holiday.Name})
+	}
+	for _, dateText := range document.Calendar.ExcludedDates {
+		date, err := time.Parse(time.DateOnly, dateText)
+		if err != nil {
+			return nil, fmt.Errorf("excluded date %q is not a date", dateText)
+		}
+		calendar.ExcludedDates = append(calendar.ExcludedDates, date)
+	}
+	business.Calendar = calendar
+	var err error
+	if business.Location, err = decodeLocation(document.Location); err != nil {
+		return nil, err
+	}
+	return business, nil
+}
+
+// parseDurationField parses a duration field; its range is checked by the schedule's Validate method.
+func parseDurationField(fieldName string, text string) (time.Duration, error) {
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s %q is not a duration", fieldName, text)
+	}
+	return duration, nil
+}
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CompletionStore records which runs of one-shot schedules have completed, so that the scheduler
+// neither repeats them nor treats them as overdue. See Scheduler.SetCompletionStore.
+type CompletionStore interface {
+	IsCompleted(taskID string, scheduledAt time.Time) (bool, error)
+	MarkCompleted(taskID string, scheduledAt time.Time) error
+}
+
+// MemoryCompletionStore keeps completions in memory; they are lost when the process exits.
+// It is the default store of a Scheduler.
+type MemoryCompletionStore struct {
+	completions map[string][]time.Time
+	mutex       sync.Mutex
+}
+
+// NewMemoryCompletionStore creates an empty in-memory completion store.
+func NewMemoryCompletionStore() *MemoryCompletionStore {
+	return &MemoryCompletionStore{completions: make(map[string][]time.Time)}
+}
+
+// IsCompleted reports whether the run of the task scheduled at scheduledAt has completed.
+func (store *MemoryCompletionStore) IsCompleted(taskID string, scheduledAt time.Time) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return containsInstant(store.completions[taskID], scheduledAt), nil
+}
+
+// MarkCompleted records that the run of the task scheduled at scheduledAt has completed.
+func (store *MemoryCompletionStore) MarkCompleted(taskID string, scheduledAt time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if !containsInstant(store.completions[taskID], scheduledAt) {
+		store.completions[taskID] = append(store.completions[taskID], scheduledAt)
+	}
+	return nil
+}
+
+// FileCompletionStore keeps completions in a JSON file so that one-shot runs survive restarts.
+type FileCompletionStore struct {
+	path   string
+	memory *MemoryCompletionStore
+}
+
+// NewFileCompletionStore opens the completion file at path, which need not exist yet.
+func NewFileCompletionStore(path string) (*FileCompletionStore, error) {
+	store := &FileCompletionStore{path: path, memory: NewMemoryCompletionStore()}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.memory.completions); err != nil {
+		return nil, fmt.Errorf("reading completion file %s: %w", path, err)
+	}
+	if store.memory.completions == nil {
+		store.memory.completions = make(map[string][]time.Time)
+	}
+	return store, nil
+}
+
+// IsCompleted reports whether the run of the task scheduled at scheduledAt has completed.
+func (store *FileCompletionStore) IsCompleted(taskID string, scheduledAt time.Time) (bool, error) {
+	return store.memory.IsCompleted(taskID, scheduledAt)
+}
+
+// MarkCompleted rewrites the file with the completion and then records it in memory, so that a
+// completion that failed to be written is not reported as recorded.
+func (store *FileCompletionStore) MarkCompleted(taskID string, scheduledAt time.Time) error {
+	store.memory.mutex.Lock()
+	defer store.memory.mutex.Unlock()
+
+	if containsInstant(store.memory.completions[taskID], scheduledAt) {
+		return nil
+	}
+	completions := make(map[string][]time.Time, len(store.memory.completions)+1)
+	for completedTaskID, instants := range store.memory.completions {
+		completions[completedTaskID] = instants
+	}
+	completions[taskID] = append(append([]time.Time(nil), completions[taskID]...), scheduledAt)
+	if err := store.writeFile(completions); err != nil {
+		return err
+	}
+	store.memory.completions = completions
+	return nil
+}
+
+// writeFile replaces the completion file with completions.
+func (store *FileCompletionStore) writeFile(completions map[string][]time.Time) error {
+	data, err := json.MarshalIndent(completions, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write a temporary file first so a crash cannot leave a truncated file behind.
+	temporaryFile, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporaryFile.Name())
+	if _, err := temporaryFile.Write(data); err != nil {
+		temporaryFile.Close()
+		return err
+	}
+	if err := temporaryFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporaryFile.Name(), store.path)
+}
+
+// containsInstant reports whether instants holds the same instant as target.
+func containsInstant(instants []time.Time, target time.Time) bool {
+	for _, instant := range instants {
+		if instant.Equal(target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,396 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// compositeSearchLimit bounds the number of candidate runs a composite schedule examines in a single NextRun.
+const compositeSearchLimit = 100000
+
+// Window is a recurring or fixed set of time ranges used to filter the runs of a schedule.
+type Window interface {
+	// Range returns the [start, end) range of the window that contains t or, when t is outside
+	// the window, the next range that opens after t. ok is false when the window never opens again.
+	Range(t time.Time) (start time.Time, end time.Time, ok bool)
+	Description() string
+}
+
+// AnyOfSchedule runs whenever any of its schedules runs.
+type AnyOfSchedule struct {
+	Schedules []TimeSchedule
+}
+
+// AnyOf combines schedules into one that runs at every run time of each of them.
+func AnyOf(schedules ...TimeSchedule) AnyOfSchedule {
+	return AnyOfSchedule{Schedules: schedules}
+}
+
+// NextRun returns the earliest next run time of the combined schedules.
+func (anyOf AnyOfSchedule) NextRun(after time.Time) *time.Time {
+	var earliestRun *time.Time
+	for _, schedule := range anyOf.Schedules {
+		nextRunTime := schedule.NextRun(after)
+		if nextRunTime != nil && (earliestRun == nil || nextRunTime.Before(*earliestRun)) {
+			earliestRun = nextRunTime
+		}
+	}
+	return earliestRun
+}
+
+// Description returns a description of the union.
+func (anyOf AnyOfSchedule) Description() string {
+	return "Any of: " + describeSchedules(anyOf.Schedules)
+}
+
+// Validate checks that there is at least one schedule and that each is valid.
+func (anyOf AnyOfSchedule) Validate() error {
+	return validateScheduleList("AnyOfSchedule", anyOf.Schedules)
+}
+
+// AllOfSchedule runs only at instants at which all of its schedules run.
+type AllOfSchedule struct {
+	Schedules []TimeSchedule
+}
+
+// AllOf combines schedules into one that runs only when every one of them runs at the same instant.
+func AllOf(schedules ...TimeSchedule) AllOfSchedule {
+	return AllOfSchedule{Schedules: schedules}
+}
+
+// NextRun returns the next instant after the provided time shared by all combined schedules.
+func (allOf AllOfSchedule) NextRun(after time.Time) *time.Time {
+	if len(allOf.Schedules) == 0 {
+		return nil
+	}
+	searchFrom := after
+	for attempt := 0; attempt < compositeSearchLimit; attempt++ {
+		var latestRun *time.Time
+		allEqual := true
+		for _, schedule := range allOf.Schedules {
+			nextRunTime := schedule.NextRun(searchFrom)
+			if nextRunTime == nil {
+				return nil
+			}
+			if latestRun != nil && !nextRunTime.Equal(*latestRun) {
+				allEqual = false
+			}
+			if latestRun == nil || nextRunTime.After(*latestRun) {
+				latestRun = nextRunTime
+			}
+		}
+		if allEqual {
+			return latestRun
+		}
+		// No schedule can agree before the latest candidate, so search again from just before it.
+		searchFrom = latestRun.Add(-time.Nanosecond)
+	}
+	return nil
+}
+
+// Description returns a description of the intersection.
+func (allOf AllOfSchedule) Description() string {
+	return "All of: " + describeSchedules(allOf.Schedules)
+}
+
+// Validate checks that there is at least one schedule and that each is valid.
+func (allOf AllOfSchedule) Validate() error {
+	return validateScheduleList("AllOfSchedule", allOf.Schedules)
+}
+
+// WithinSchedule keeps only the runs of a schedule that fall inside all of its windows.
+type WithinSchedule struct {
+	Schedule TimeSchedule
+	Windows  []Window
+}
+
+// Within restricts schedule to the runs that fall inside every one of windows.
+func Within(schedule TimeSchedule, windows ...Window) WithinSchedule {
+	return WithinSchedule{Schedule: schedule, Windows: windows}
+}
+
+// NextRun returns the next run of the schedule after the provided time that lies inside the windows.
+func (within WithinSchedule) NextRun(after time.Time) *time.Time {
+	searchFrom := after
+	for attempt := 0; attempt < compositeSearchLimit; attempt++ {
+		nextRunTime := within.Schedule.NextRun(searchFrom)
+		if nextRunTime == nil {
+			return nil
+		}
+		inside := true
+		resumeAt := *nextRunTime
+		for _, window := range within.Windows {
+			windowStart, _, ok := window.Range(*nextRunTime)
+			if !ok {
+				return nil
+			}
+			if windowStart.After(*nextRunTime) {
+				inside = false
+				if windowStart.After(resumeAt) {
+					resumeAt = windowStart
+				}
+			}
+		}
+		if inside {
+			return nextRunTime
+		}
+		// Skip straight to the opening of the window that is closed the longest.
+		searchFrom = resumeAt.Add(-time.Nanosecond)
+	}
+	return nil
+}
+
+// Description returns a description of the restricted schedule.
+func (within WithinSchedule) Description() string {
+	return within.Schedule.Description() + ", " + strings.Join(describeWindows(within.Windows), " and ")
+}
+
+// Validate checks the wrapped schedule and windows.
+func (within WithinSchedule) Validate() error {
+	if err := validateNested("WithinSchedule", "Schedule", within.Schedule); err != nil {
+		return err
+	}
+	return validateWindows("WithinSchedule", within.Windows)
+}
+
+// ExceptSchedule drops the runs of a schedule that fall inside any of its windows.
+type ExceptSchedule struct {
+	Schedule TimeSchedule
+	Windows  []Window
+}
+
+// Except removes from schedule the runs that fall inside any of windows, such as maintenance windows.
+func Except(schedule TimeSchedule, windows ...Window) ExceptSchedule {
+	return ExceptSchedule{Schedule: schedule, Windows: windows}
+}
+
+// NextRun returns the next run of the schedule after the provided time that lies outside the windows.
+func (except ExceptSchedule) NextRun(after time.Time) *time.Time {
+	searchFrom := after
+	for attempt := 0; attempt < compositeSearchLimit; attempt++ {
+		nextRunTime := except.Schedule.NextRun(searchFrom)
+		if nextRunTime == nil {
+			return nil
+		}
+		excluded := false
+		resumeAt := *nextRunTime
+		for _, window := range except.Windows {
+			windowStart, windowEnd, ok := window.Range(*nextRunTime)
+			if ok && !windowStart.After(*nextRunTime) {
+				excluded = true
+				if windowEnd.After(resumeAt) {
+					resumeAt = windowEnd
+				}
+			}
+		}
+		if !excluded {
+			return nextRunTime
+		}
+		// Skip straight to the end of the window; a run at the closing instant is allowed.
+		searchFrom = resumeAt.Add(-time.Nanosecond)
+	}
+	return nil
+}
+
+// Description returns a description of the schedule with its exclusions.
+func (except ExceptSchedule) Description() string {
+	return except.Schedule.Description() + ", except " + strings.Join(describeWindows(except.Windows), " or ")
+}
+
+// Validate checks the wrapped schedule and windows.
+func (except ExceptSchedule) Validate() error {
+	if err := validateNested("ExceptSchedule", "Schedule", except.Schedule); err != nil {
+		return err
+	}
+	return validateWindows("ExceptSchedule", except.Windows)
+}
+
+// TimeOfDayWindow is open between two wall-clock times of day, optionally only on some weekdays.
+type TimeOfDayWindow struct {
+	Start    time.Duration  // Time of day at which the window opens, as an offset from midnight.
+	End      time.Duration  // Time of day at which the window closes; at or before Start means the next day.
+	Weekdays []time.Weekday // Days on which the window opens; nil means every day.
+	Location *time.Location // Optional time zone; see WithLocation.
+}
+
+// Between returns a window open every day from start until end, given as offsets from midnight
+// such as 9*time.Hour. A window whose end is at or before its start closes on the following day.
+func Between(start time.Duration, end time.Duration) TimeOfDayWindow {
+	return TimeOfDayWindow{Start: start, End: end}
+}
+
+// On returns a copy of the window that only opens on the given weekdays.
+func (window TimeOfDayWindow) On(weekdays ...time.Weekday) TimeOfDayWindow {
+	window.Weekdays = weekdays
+	return window
+}
+
+// In returns a copy of the window evaluated in the given time zone.
+func (window TimeOfDayWindow) In(location *time.Location) TimeOfDayWindow {
+	window.Location = location
+	return window
+}
+
+// Range returns the range of the window that contains t or opens next after it.
+func (window TimeOfDayWindow) Range(t time.Time) (time.Time, time.Time, bool) {
+	location := resolveLocation(window.Location, t)
+	localTime := t.In(location)
+	closingDayOffset := 0
+	if window.End <= window.Start {
+		closingDayOffset = 1
+	}
+	// Start a day early to catch a window that opened yesterday and closes after midnight.
+	for dayOffset := -1; dayOffset <= 8; dayOffset++ {
+		openingDay := time.Date(localTime.Year(), localTime.Month(), localTime.Day()+dayOffset, 0, 0, 0, 0, location)
+		if window.Weekdays != nil && !containsWeekday(window.Weekdays, openingDay.Weekday()) {
+			continue
+		}
+		windowStart := time.Date(openingDay.Year(), openingDay.Month(), openingDay.Day(), 0, 0, 0, int(window.Start), location)
+		windowEnd := time.Date(openingDay.Year(), openingDay.Month(), openingDay.Day()+closingDayOffset, 0, 0, 0, int(window.End), location)
+		if windowEnd.After(t) {
+			return windowStart, windowEnd, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// Description returns a description such as "between 09:00 and 17:00 on weekdays".
+func (window TimeOfDayWindow) Description() string {
+	description := fmt.Sprintf("between %s and %s", formatTimeOfDay(window.Start), formatTimeOfDay(window.End))
+	if window.Weekdays != nil {
+		description += " on " + describeWeekdays(window.Weekdays)
+	}
+	return description + describeLocation(window.Location)
+}
+
+// Validate checks that Start and End are times of day and that Weekdays, if set, is not empty.
+func (window TimeOfDayWindow) Validate() error {
+	for _, offset := range []struct {
+		field string
+		value time.Duration
+	}{{"Start", window.Start}, {"End", window.End}} {
+		if offset.value < 0 || offset.value > 24*time.Hour {
+			return &ScheduleFieldError{Schedule: "TimeOfDayWindow", Field: offset.field, Reason: fmt.Sprintf("must be between 0s and 24h0m0s, got %s", offset.value)}
+		}
+	}
+	if window.Weekdays != nil {
+		return validateWeekdays("TimeOfDayWindow", "Weekdays", window.Weekdays)
+	}
+	return nil
+}
+
+// ScheduleWindow is open for a fixed duration from each run of a schedule, such as a two-hour
+// maintenance window every Sunday at 02:00.
+type ScheduleWindow struct {
+	Schedule TimeSchedule
+	Duration time.Duration
+}
+
+// Range returns the range of the window that contains t or opens next after it.
+func (window ScheduleWindow) Range(t time.Time) (time.Time, time.Time, bool) {
+	// The first run after t-Duration opens the first window that is still open after t.
+	windowStart := window.Schedule.NextRun(t.Add(-window.Duration))
+	if windowStart == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return *windowStart, windowStart.Add(window.Duration), true
+}
+
+// Description returns a description of the window.
+func (window ScheduleWindow) Description() string {
+	return fmt.Sprintf("for %s from %s", window.Duration, window.Schedule.Description())
+}
+
+// Validate checks the schedule and that Duration is positive.
+func (window ScheduleWindow) Validate() error {
+	if err := validateNested("ScheduleWindow", "Schedule", window.Schedule); err != nil {
+		return err
+	}
+	if window.Duration <= 0 {
+		return &ScheduleFieldError{Schedule: "ScheduleWindow", Field: "Duration", Reason: fmt.Sprintf("must be positive, got %s", window.Duration)}
+	}
+	return nil
+}
+
+// DateRangeWindow is open once, from Start until End.
+type DateRangeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Range returns the range of the window unless it has already closed at t.
+func (window DateRangeWindow) Range(t time.Time) (time.Time, time.Time, bool) {
+	if !window.End.After(t) {
+		return time.Time{}, time.Time{}, false
+	}
+	return window.Start, window.End, true
+}
+
+// Description returns a description of the window.
+func (window DateRangeWindow) Description() string {
+	return fmt.Sprintf("from %s to %s", window.Start.Format("2006-01-02 15:04 MST"), window.End.Format("2006-01-02 15:04 MST"))
+}
+
+// Validate checks that End is after Start.
+func (window DateRangeWindow) Validate() error {
+	if !window.End.After(window.Start) {
+		return &ScheduleFieldError{Schedule: "DateRangeWindow", Field: "End", Reason: "must be after Start"}
+	}
+	return nil
+}
+
+// describeSchedules joins the descriptions of schedules with semicolons.
+func describeSchedules(schedules []TimeSchedule) string {
+	descriptions := make([]string, len(schedules))
+	for index, schedule := range schedules {
+		descriptions[index] = schedule.Description()
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// describeWindows returns the description of each window.
+func describeWindows(windows []Window) []string {
+	descriptions := make([]string, len(windows))
+	for index, window := range windows {
+		descriptions[index] = window.Description()
+	}
+	return descriptions
+}
+
+// describeWeekdays renders weekdays as "weekdays", "weekends" or a list of day names.
+func describeWeekdays(weekdays []time.Weekday) string {
+	workWeek := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	weekend := []time.Weekday{time.Saturday, time.Sunday}
+	if sameWeekdays(weekdays, workWeek) {
+		return "weekdays"
+	}
+	if sameWeekdays(weekdays, weekend) {
+		return "weekends"
+	}
+	names := make([]string, len(weekdays))
+	for index, weekday := range weekdays {
+		names[index] = weekday.String()
+	}
+	return joinWithAnd(names)
+}
+
+// sameWeekdays reports whether two weekday lists hold the same days.
+func sameWeekdays(first []time.Weekday, second []time.Weekday) bool {
+	for _, weekday := range first {
+		if !containsWeekday(second, weekday) {
+			return false
+		}
+	}
+	for _, weekday := range second {
+		if !containsWeekday(first, weekday) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatTimeOfDay renders an offset from midnight as "HH:MM".
+func formatTimeOfDay(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
@@ -0,0 +1,735 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds how far ahead NextRun searches for a matching time.
+const cronSearchYears = 50
+
+// cronFieldKind distinguishes the day fields, which accept extra syntax, from the others.
+type cronFieldKind int
+
+const (
+	cronGenericField cronFieldKind = iota
+	cronDayOfMonthField
+	cronDayOfWeekField
+)
+
+// cronFieldSpec describes the allowed values of a single cron field.
+type cronFieldSpec struct {
+	name    string
+	kind    cronFieldKind
+	minimum int
+	maximum int
+	names   map[string]int
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var (
+	cronSecondSpec     = cronFieldSpec{name: "second", minimum: 0, maximum: 59}
+	cronMinuteSpec     = cronFieldSpec{name: "minute", minimum: 0, maximum: 59}
+	cronHourSpec       = cronFieldSpec{name: "hour", minimum: 0, maximum: 23}
+	cronDayOfMonthSpec = cronFieldSpec{name: "day-of-month", kind: cronDayOfMonthField, minimum: 1, maximum: 31}
+	cronMonthSpec      = cronFieldSpec{name: "month", minimum: 1, maximum: 12, names: cronMonthNames}
+	cronDayOfWeekSpec  = cronFieldSpec{name: "day-of-week", kind: cronDayOfWeekField, minimum: 0, maximum: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+	cronQuartzDayOfWeekSpec = cronFieldSpec{name: "day-of-week", kind: cronDayOfWeekField, minimum: 1, maximum: 7, names: map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}}
+	cronYearSpec = cronFieldSpec{name: "year", minimum: 1970, maximum: 2099}
+)
+
+// cronMacros maps the supported @-macros to their five-field equivalents.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronItem is one comma-separated element of a cron field, such as "5", "1-5" or "*/15".
+type cronItem struct {
+	start    int
+	end      int
+	step     int
+	wildcard bool
+}
+
+// cronDayRuleKind identifies the Quartz day extensions.
+type cronDayRuleKind int
+
+const (
+	cronLastDay        cronDayRuleKind = iota // "L" or "L-3" in day of month
+	cronLastWeekday                           // "LW" in day of month
+	cronNearestWeekday                        // "15W" in day of month
+	cronLastDayOfWeek                         // "5L" in day of week
+	cronNthDayOfWeek                          // "5#3" in day of week
+)
+
+// cronDayRule is a day-of-month or day-of-week element that cannot be expressed as a plain value set.
+type cronDayRule struct {
+	kind   cronDayRuleKind
+	value  int
+	offset int
+}
+
+// matches reports whether the rule selects the given date.
+func (rule cronDayRule) matches(date time.Time) bool {
+	monthLength := daysInMonth(date.Year(), date.Month())
+	switch rule.kind {
+	case cronLastDay:
+		return date.Day() == monthLength-rule.offset
+	case cronLastWeekday:
+		return date.Day() == nearestWeekday(date.Year(), date.Month(), monthLength)
+	case cronNearestWeekday:
+		return rule.value <= monthLength && date.Day() == nearestWeekday(date.Year(), date.Month(), rule.value)
+	case cronLastDayOfWeek:
+		return int(date.Weekday()) == rule.value && date.Day()+7 > monthLength
+	case cronNthDayOfWeek:
+		return int(date.Weekday()) == rule.value && (date.Day()-1)/7+1 == rule.offset
+	}
+	return false
+}
+
+// description renders the rule as an English phrase.
+func (rule cronDayRule) description() string {
+	switch rule.kind {
+	case cronLastDay:
+		if rule.offset == 0 {
+			return "on the last day of the month"
+		}
+		return fmt.Sprintf("on the last day of the month minus %s", pluralizeUnit(rule.offset, "day"))
+	case cronLastWeekday:
+		return "on the last weekday of the month"
+	case cronNearestWeekday:
+		return fmt.Sprintf("on the weekday nearest day %d of the month", rule.value)
+	case cronLastDayOfWeek:
+		return fmt.Sprintf("on the last %s of the month", formatCronWeekday(rule.value))
+	case cronNthDayOfWeek:
+		return fmt.Sprintf("on the %s %s of the month", ordinalWord(rule.offset), formatCronWeekday(rule.value))
+	}
+	return ""
+}
+
+// cronField holds the parsed values of a single cron field.
+type cronField struct {
+	spec     cronFieldSpec
+	items    []cronItem
+	rules    []cronDayRule
+	allowed  []bool
+	wildcard bool
+	starred  bool // Whether the field starts with "*", as in "*/2": a day field is then not restricted for the day rule.
+}
+
+// matches reports whether value is allowed by the field.
+func (field cronField) matches(value int) bool {
+	if value < 0 || value >= len(field.allowed) {
+		return false
+	}
+	return field.allowed[value]
+}
+
+// matchesDate reports whether a day field selects the given date, either by value or by rule.
+func (field cronField) matchesDate(value int, date time.Time) bool {
+	if field.matches(value) {
+		return true
+	}
+	for _, rule := range field.rules {
+		if rule.matches(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// CronSchedule runs a task according to a cron expression.
+//
+// A five-field expression is the standard crontab format: minute, hour, day of month,
+// month and day of week (0-7, where both 0 and 7 are Sunday). Each field accepts "*",
+// single values, ranges ("1-5"), steps ("*/15", "0-30/10") and comma separated lists.
+// Months and weekdays may also be written as names (JAN, MON). The macros @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported.
+//
+// Six- and seven-field expressions follow the Quartz format: second, minute, hour,
+// day of month, month, day of week (1-7, where 1 is Sunday) and an optional year
+// (1970-2099). The day fields accept "?" for "no specific value", and the Quartz
+// extensions "L" (last day), "L-3" (third to last day), "LW" (last weekday), "15W"
+// (weekday nearest the 15th), "FRIL" or "6L" (last Friday) and "FRI#3" or "6#3"
+// (third Friday). The extensions are also accepted in five-field expressions, where
+// weekdays keep their crontab numbering.
+//
+// When both day of month and day of week are restricted, a day matches if either does. As in
+// crontab, a day field starting with "*", such as "*/2", does not count as restricted for this
+// rule, so "0 0 */2 * 1" runs on Mondays that fall on an odd day of the month.
+//
+// An expression may start with "CRON_TZ=<zone>" or "TZ=<zone>" to evaluate it in the
+// named IANA time zone, which is the same as setting Location.
+type CronSchedule struct {
+	Location    *time.Location // Optional time zone; see WithLocation.
+	DST         DSTPolicy      // Handling of days with a daylight saving transition.
+	expression  string
+	seconds     cronField
+	minutes     cronField
+	hours       cronField
+	daysOfMonth cronField
+	months      cronField
+	daysOfWeek  cronField
+	years       cronField
+}
+
+// NewCronSchedule parses a cron expression into a CronSchedule.
+func NewCronSchedule(expression string) (CronSchedule, error) {
+	trimmedExpression := strings.TrimSpace(expression)
+	fieldsExpression := trimmedExpression
+	var location *time.Location
+	if strings.HasPrefix(fieldsExpression, "CRON_TZ=") || strings.HasPrefix(fieldsExpression, "TZ=") {
+		zoneAssignment, remainingExpression, _ := strings.Cut(fieldsExpression, " ")
+		_, zoneName, _ := strings.Cut(zoneAssignment, "=")
+		loadedLocation, err := time.LoadLocation(zoneName)
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("%w: unknown time zone %q: %v", ErrInvalidCronExpression, zoneName, err)
+		}
+		location = loadedLocation
+		fieldsExpression = strings.TrimSpace(remainingExpression)
+	}
+	if strings.HasPrefix(fieldsExpression, "@") {
+		macroExpression, exists := cronMacros[strings.ToLower(fieldsExpression)]
+		if !exists {
+			return CronSchedule{}, fmt.Errorf("%w: unknown macro %q", ErrInvalidCronExpression, fieldsExpression)
+		}
+		fieldsExpression = macroExpression
+	}
+
+	fieldTexts := strings.Fields(fieldsExpression)
+	var fieldSpecs []cronFieldSpec
+	switch len(fieldTexts) {
+	case 5:
+		fieldTexts = append([]string{"0"}, fieldTexts...)
+		fieldSpecs = []cronFieldSpec{cronSecondSpec, cronMinuteSpec, cronHourSpec, cronDayOfMonthSpec, cronMonthSpec, cronDayOfWeekSpec}
+	case 6, 7:
+		fieldSpecs = []cronFieldSpec{cronSecondSpec, cronMinuteSpec, cronHourSpec, cronDayOfMonthSpec, cronMonthSpec, cronQuartzDayOfWeekSpec}
+	default:
+		return CronSchedule{}, fmt.Errorf("%w: expected 5, 6 or 7 fields, got %d in %q", ErrInvalidCronExpression, len(fieldTexts), expression)
+	}
+	if len(fieldTexts) == 6 {
+		fieldTexts = append(fieldTexts, "*")
+	}
+	fieldSpecs = append(fieldSpecs, cronYearSpec)
+
+	parsedFields := make([]cronField, len(fieldSpecs))
+	for index, fieldSpec := range fieldSpecs {
+		parsedField, err := parseCronField(fieldTexts[index], fieldSpec)
+		if err != nil {
+			return CronSchedule{}, err
+		}
+		parsedFields[index] = parsedField
+	}
+
+	return CronSchedule{
+		Location:    location,
+		expression:  trimmedExpression,
+		seconds:     parsedFields[0],
+		minutes:     parsedFields[1],
+		hours:       parsedFields[2],
+		daysOfMonth: parsedFields[3],
+		months:      parsedFields[4],
+		daysOfWeek:  normalizeCronWeekdays(parsedFields[5]),
+		years:       parsedFields[6],
+	}, nil
+}
+
+// MustCronSchedule is like NewCronSchedule but panics if the expression is invalid.
+// It simplifies initialization of schedules in package-level variables and init functions.
+func MustCronSchedule(expression string) CronSchedule {
+	cronSchedule, err := NewCronSchedule(expression)
+	if err != nil {
+		panic(err)
+	}
+	return cronSchedule
+}
+
+// Expression returns the cron expression the schedule was parsed from.
+func (cron CronSchedule) Expression() string {
+	return cron.expression
+}
+
+// Validate checks that the schedule was created by NewCronSchedule; the zero value has no fields to match.
+func (cron CronSchedule) Validate() error {
+	if cron.expression == "" {
+		return &ScheduleFieldError{Schedule: "CronSchedule", Field: "expression", Reason: "is empty; use NewCronSchedule"}
+	}
+	return nil
+}
+
+// NextRun returns the next run time after the provided time.
+func (cron CronSchedule) NextRun(after time.Time) *time.Time {
+	return nextWallClockRun(after, resolveLocation(cron.Location, after), cron.DST, cron.nextMatch)
+}
+
+// nextMatch returns the earliest wall-clock time at or after wallClock that satisfies
+// every field. Wall-clock times are represented in UTC so that no DST arithmetic applies.
+func (cron CronSchedule) nextMatch(wallClock time.Time) (time.Time, bool) {
+	yearLimit := wallClock.Year() + cronSearchYears
+	if !cron.years.wildcard {
+		yearLimit = min(yearLimit, cronYearSpec.maximum)
+	}
+	for wallClock.Year() <= yearLimit {
+		if !cron.years.matches(wallClock.Year()) {
+			wallClock = time.Date(wallClock.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !cron.months.matches(int(wallClock.Month())) {
+			wallClock = time.Date(wallClock.Year(), wallClock.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !cron.matchesDay(wallClock) {
+			wallClock = time.Date(wallClock.Year(), wallClock.Month(), wallClock.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !cron.hours.matches(wallClock.Hour()) {
+			wallClock = wallClock.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !cron.minutes.matches(wallClock.Minute()) {
+			wallClock = wallClock.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if !cron.seconds.matches(wallClock.Second()) {
+			wallClock = wallClock.Add(time.Second)
+			continue
+		}
+		return wallClock, true
+	}
+	return time.Time{}, false
+}
+
+// matchesDay applies the cron day-of-month and day-of-week rules to the given date.
+func (cron CronSchedule) matchesDay(wallClock time.Time) bool {
+	dayOfMonthMatches := cron.daysOfMonth.matchesDate(wallClock.Day(), wallClock)
+	dayOfWeekMatches := cron.daysOfWeek.matchesDate(int(wallClock.Weekday()), wallClock)
+	if cron.daysOfMonth.starred || cron.daysOfWeek.starred {
+		return dayOfMonthMatches && dayOfWeekMatches
+	}
+	return dayOfMonthMatches || dayOfWeekMatches
+}
+
+// Description returns a plain English description of the cron schedule.
+func (cron CronSchedule) Description() string {
+	var phrases []string
+	phrases = append(phrases, cron.describeTime())
+	if dayPhrase := cron.describeDays(); dayPhrase != "" {
+		phrases = append(phrases, dayPhrase)
+	}
+	if !cron.months.wildcard {
+		phrases = append(phrases, describeCronField(cron.months, "in", "month", formatCronMonth))
+	}
+	if !cron.years.wildcard {
+		phrases = append(phrases, describeCronField(cron.years, "in", "year", strconv.Itoa))
+	}
+	return strings.Join(phrases, ", ") + describeLocation(cron.Location)
+}
+
+// describeTime describes the second, minute and hour fields.
+func (cron CronSchedule) describeTime() string {
+	secondValues, secondsAreList := cronListValues(cron.seconds)
+	minuteValues, minutesAreList := cronListValues(cron.minutes)
+	hourValues, hoursAreList := cronListValues(cron.hours)
+	if secondsAreList && minutesAreList && hoursAreList && len(secondValues)*len(minuteValues)*len(hourValues) <= 6 {
+		var clockTimes []string
+		for _, hour := range hourValues {
+			for _, minute := range minuteValues {
+				for _, second := range secondValues {
+					if len(secondValues) == 1 && second == 0 {
+						clockTimes = append(clockTimes, fmt.Sprintf("%02d:%02d", hour, minute))
+					} else {
+						clockTimes = append(clockTimes, fmt.Sprintf("%02d:%02d:%02d", hour, minute, second))
+					}
+				}
+			}
+		}
+		return "At " + joinWithAnd(clockTimes)
+	}
+
+	minutePhrase := describeCronField(cron.minutes, "at minute", "minute", strconv.Itoa)
+	switch {
+	case !cron.hours.wildcard:
+		minutePhrase += ", " + describeCronField(cron.hours, "past hour", "hour", strconv.Itoa)
+	case !cron.minutes.wildcard && !isCronWildcardStep(cron.minutes):
+		minutePhrase += " past every hour"
+	}
+
+	if secondsAreList && len(secondValues) == 1 && secondValues[0] == 0 {
+		return capitalize(minutePhrase)
+	}
+	secondPhrase := describeCronField(cron.seconds, "at second", "second", strconv.Itoa)
+	if cron.minutes.wildcard && cron.hours.wildcard {
+		return capitalize(secondPhrase)
+	}
+	return capitalize(secondPhrase + ", " + minutePhrase)
+}
+
+// describeDays describes the day-of-month and day-of-week fields.
+func (cron CronSchedule) describeDays() string {
+	dayOfMonthPhrase := ""
+	if !cron.daysOfMonth.wildcard {
+		var dayOfMonthPhrases []string
+		if len(cron.daysOfMonth.items) > 0 {
+			dayOfMonthPhrases = append(dayOfMonthPhrases, describeCronField(cron.daysOfMonth, "on day", "day", strconv.Itoa)+" of the month")
+		}
+		for _, rule := range cron.daysOfMonth.rules {
+			dayOfMonthPhrases = append(dayOfMonthPhrases, rule.description())
+		}
+		dayOfMonthPhrase = joinWithAnd(dayOfMonthPhrases)
+	}
+	dayOfWeekPhrase := ""
+	if !cron.daysOfWeek.wildcard {
+		var dayOfWeekPhrases []string
+		if len(cron.daysOfWeek.items) > 0 {
+			dayOfWeekPhrases = append(dayOfWeekPhrases, describeCronField(cron.daysOfWeek, "on", "day of the week", formatCronWeekday))
+		}
+		for _, rule := range cron.daysOfWeek.rules {
+			dayOfWeekPhrases = append(dayOfWeekPhrases, rule.description())
+		}
+		dayOfWeekPhrase = joinWithAnd(dayOfWeekPhrases)
+	}
+	switch {
+	case dayOfMonthPhrase != "" && dayOfWeekPhrase != "":
+		if cron.daysOfMonth.starred || cron.daysOfWeek.starred {
+			return dayOfMonthPhrase + ", " + dayOfWeekPhrase
+		}
+		return dayOfMonthPhrase + " or " + dayOfWeekPhrase
+	case dayOfMonthPhrase != "":
+		return dayOfMonthPhrase
+	case dayOfWeekPhrase != "":
+		return dayOfWeekPhrase
+	case !cron.months.wildcard:
+		return ""
+	}
+	_, secondsAreList := cronListValues(cron.seconds)
+	_, minutesAreList := cronListValues(cron.minutes)
+	_, hoursAreList := cronListValues(cron.hours)
+	if secondsAreList && minutesAreList && hoursAreList {
+		return "every day"
+	}
+	return ""
+}
+
+// parseCronField parses the text of one cron field according to its spec.
+func parseCronField(fieldText string, fieldSpec cronFieldSpec) (cronField, error) {
+	parsedField := cronField{
+		spec:    fieldSpec,
+		allowed: make([]bool, fieldSpec.maximum+1),
+	}
+	if fieldText == "?" {
+		if fieldSpec.kind == cronGenericField {
+			return cronField{}, cronFieldError(fieldSpec, fieldText, "\"?\" is only allowed in the day fields")
+		}
+		fieldText = "*"
+	}
+	for _, itemText := range strings.Split(fieldText, ",") {
+		if fieldSpec.kind != cronGenericField {
+			rule, isRule, err := parseCronDayRule(itemText, fieldSpec)
+			if err != nil {
+				return cronField{}, err
+			}
+			if isRule {
+				parsedField.rules = append(parsedField.rules, rule)
+				continue
+			}
+		}
+		parsedItem, err := parseCronItem(itemText, fieldSpec)
+		if err != nil {
+			return cronField{}, err
+		}
+		parsedField.items = append(parsedField.items, parsedItem)
+		for value := parsedItem.start; value <= parsedItem.end; value += parsedItem.step {
+			parsedField.allowed[value] = true
+		}
+	}
+	parsedField.wildcard = fieldText == "*"
+	parsedField.starred = strings.HasPrefix(fieldText, "*")
+	return parsedField, nil
+}
+
+// parseCronDayRule recognizes the Quartz day extensions: "L", "L-n", "LW" and "nW" in the
+// day-of-month field, and "L", "nL" and "n#k" in the day-of-week field. It reports false
+// when the element is an ordinary value, range or step.
+func parseCronDayRule(itemText string, fieldSpec cronFieldSpec) (cronDayRule, bool, error) {
+	upperText := strings.ToUpper(itemText)
+	if fieldSpec.kind == cronDayOfMonthField {
+		switch {
+		case upperText == "L":
+			return cronDayRule{kind: cronLastDay}, true, nil
+		case upperText == "LW":
+			return cronDayRule{kind: cronLastWeekday}, true, nil
+		case strings.HasPrefix(upperText, "L-"):
+			offset, err := strconv.Atoi(upperText[2:])
+			if err != nil || offset < 0 || offset > 30 {
+				return cronDayRule{}, false, cronFieldError(fieldSpec, itemText, "offset from the last day must be between 0 and 30")
+			}
+			return cronDayRule{kind: cronLastDay, offset: offset}, true, nil
+		case strings.HasSuffix(upperText, "W"):
+			day, err := parseCronValue(upperText[:len(upperText)-1], fieldSpec)
+			if err != nil {
+				return cronDayRule{}, false, err
+			}
+			return cronDayRule{kind: cronNearestWeekday, value: day}, true, nil
+		}
+		return cronDayRule{}, false, nil
+	}
+
+	switch {
+	case upperText == "L":
+		// A bare "L" in the day-of-week field is the last day of the week, Saturday.
+		return cronDayRule{}, false, nil
+	case strings.HasSuffix(upperText, "L"):
+		weekday, err := parseCronValue(upperText[:len(upperText)-1], fieldSpec)
+		if err != nil {
+			return cronDayRule{}, false, err
+		}
+		return cronDayRule{kind: cronLastDayOfWeek, value: weekday}, true, nil
+	case strings.Contains(upperText, "#"):
+		weekdayText, occurrenceText, _ := strings.Cut(upperText, "#")
+		weekday, err := parseCronValue(weekdayText, fieldSpec)
+		if err != nil {
+			return cronDayRule{}, false, err
+		}
+		occurrence, err := strconv.Atoi(occurrenceText)
+		if err != nil || occurrence < 1 || occurrence > 5 {
+			return cronDayRule{}, false, cronFieldError(fieldSpec, itemText, "occurrence after \"#\" must be between 1 and 5")
+		}
+		return cronDayRule{kind: cronNthDayOfWeek, value: weekday, offset: occurrence}, true, nil
+	}
+	return cronDayRule{}, false, nil
+}
+
+// normalizeCronWeekdays converts a parsed day-of-week field to time.Weekday numbering:
+// crontab values map 7 to Sunday, and Quartz values (1 = Sunday) are shifted down by one.
+func normalizeCronWeekdays(field cronField) cronField {
+	shift := 0
+	if field.spec.minimum == 1 {
+		shift = 1
+	}
+	normalizedField := cronField{
+		spec:     field.spec,
+		allowed:  make([]bool, 7),
+		wildcard: field.wildcard,
+		starred:  field.starred,
+	}
+	for value, isAllowed := range field.allowed {
+		if isAllowed {
+			normalizedField.allowed[(value-shift+7)%7] = true
+		}
+	}
+	for _, item := range field.items {
+		item.start -= shift
+		item.end -= shift
+		normalizedField.items = append(normalizedField.items, item)
+	}
+	for _, rule := range field.rules {
+		rule.value = (rule.value - shift + 7) % 7
+		normalizedField.rules = append(normalizedField.rules, rule)
+	}
+	return normalizedField
+}
+
+// parseCronItem parses a single list element such as "*/5", "MON-FRI" or "10-50/10".
+func parseCronItem(itemText string, fieldSpec cronFieldSpec) (cronItem, error) {
+	if itemText == "" {
+		return cronItem{}, cronFieldError(fieldSpec, itemText, "empty list element")
+	}
+	rangeText, stepText, hasStep := strings.Cut(itemText, "/")
+	parsedItem := cronItem{step: 1}
+	if hasStep {
+		step, err := strconv.Atoi(stepText)
+		if err != nil || step <= 0 {
+			return cronItem{}, cronFieldError(fieldSpec, itemText, "step must be a positive number")
+		}
+		parsedItem.step = step
+	}
+
+	switch {
+	case rangeText == "L" && fieldSpec.kind == cronDayOfWeekField && !hasStep:
+		saturday := fieldSpec.names["SAT"]
+		parsedItem.start = saturday
+		parsedItem.end = saturday
+	case rangeText == "*":
+		parsedItem.wildcard = true
+		parsedItem.start = fieldSpec.minimum
+		parsedItem.end = fieldSpec.maximum
+	case strings.Contains(rangeText, "-"):
+		startText, endText, _ := strings.Cut(rangeText, "-")
+		start, err := parseCronValue(startText, fieldSpec)
+		if err != nil {
+			return cronItem{}, err
+		}
+		end, err := parseCronValue(endText, fieldSpec)
+		if err != nil {
+			return cronItem{}, err
+		}
+		if end < start {
+			return cronItem{}, cronFieldError(fieldSpec, itemText, "range end is before range start")
+		}
+		parsedItem.start = start
+		parsedItem.end = end
+	default:
+		value, err := parseCronValue(rangeText, fieldSpec)
+		if err != nil {
+			return cronItem{}, err
+		}
+		parsedItem.start = value
+		parsedItem.end = value
+		if hasStep {
+			parsedItem.end = fieldSpec.maximum
+		}
+	}
+	return parsedItem, nil
+}
+
+// parseCronValue parses a numeric or named value and checks it against the field bounds.
+func parseCronValue(valueText string, fieldSpec cronFieldSpec) (int, error) {
+	if namedValue, exists := fieldSpec.names[strings.ToUpper(valueText)]; exists {
+		return namedValue, nil
+	}
+	value, err := strconv.Atoi(valueText)
+	if err != nil {
+		return 0, cronFieldError(fieldSpec, valueText, "not a number")
+	}
+	if value < fieldSpec.minimum || value > fieldSpec.maximum {
+		return 0, cronFieldError(fieldSpec, valueText, fmt.Sprintf("value must be between %d and %d", fieldSpec.minimum, fieldSpec.maximum))
+	}
+	return value, nil
+}
+
+// cronFieldError builds an ErrInvalidCronExpression error that names the offending field.
+func cronFieldError(fieldSpec cronFieldSpec, text string, reason string) error {
+	return fmt.Errorf("%w: %s field %q: %s", ErrInvalidCronExpression, fieldSpec.name, text, reason)
+}
+
+// cronListValues returns the explicit values of a field made only of single values.
+func cronListValues(field cronField) ([]int, bool) {
+	var values []int
+	for _, item := range field.items {
+		if item.wildcard || item.start != item.end {
+			return nil, false
+		}
+		values = append(values, item.start)
+	}
+	return values, true
+}
+
+// isCronWildcardStep reports whether a field is a single "*/n" item.
+func isCronWildcardStep(field cronField) bool {
+	return len(field.items) == 1 && field.items[0].wildcard && field.items[0].step > 1
+}
+
+// describeCronField renders a field as English, e.g. "every 15 minutes" or "on Monday through Friday".
+func describeCronField(field cronField, prefix string, unit string, format func(int) string) string {
+	if field.wildcard {
+		return "every " + unit
+	}
+	var itemPhrases []string
+	var singleValues []string
+	for _, item := range field.items {
+		switch {
+		case item.wildcard:
+			itemPhrases = append(itemPhrases, fmt.Sprintf("every %s", pluralizeUnit(item.step, unit)))
+		case item.start == item.end:
+			singleValues = append(singleValues, format(item.start))
+		case item.step == 1:
+			itemPhrases = append(itemPhrases, fmt.Sprintf("%s %s through %s", prefix, format(item.start), format(item.end)))
+		default:
+			itemPhrases = append(itemPhrases, fmt.Sprintf("every %s from %s through %s",
+				pluralizeUnit(item.step, unit), format(item.start), format(item.end)))
+		}
+	}
+	if len(singleValues) > 0 {
+		itemPhrases = append([]string{prefix + " " + joinWithAnd(singleValues)}, itemPhrases...)
+	}
+	return joinWithAnd(itemPhrases)
+}
+
+// pluralizeUnit renders "minute", "2 minutes" or "3 days of the week" style phrases.
+func pluralizeUnit(count int, unit string) string {
+	if count == 1 {
+		return unit
+	}
+	head, tail, hasTail := strings.Cut(unit, " ")
+	if hasTail {
+		return fmt.Sprintf("%d %ss %s", count, head, tail)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
+
+// joinWithAnd joins phrases as "a", "a and b" or "a, b and c".
+func joinWithAnd(phrases []string) string {
+	switch len(phrases) {
+	case 0:
+		return ""
+	case 1:
+		return phrases[0]
+	default:
+		return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+	}
+}
+
+// capitalize upper-cases the first letter of a phrase.
+func capitalize(phrase string) string {
+	if phrase == "" {
+		return phrase
+	}
+	return strings.ToUpper(phrase[:1]) + phrase[1:]
+}
+
+func formatCronMonth(month int) string {
+	return time.Month(month).String()
+}
+
+func formatCronWeekday(weekday int) string {
+	return time.Weekday(weekday % 7).String()
+}
+
+// ordinalWord spells out small ordinals such as "first" and "third".
+func ordinalWord(number int) string {
+	ordinals := []string{"zeroth", "first", "second", "third", "fourth", "fifth"}
+	if number >= 0 && number < len(ordinals) {
+		return ordinals[number]
+	}
+	return strconv.Itoa(number) + "th"
+}
+
+// daysInMonth returns the number of days in the given month.
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the Monday-to-Friday day of the month closest to the given day
+// without leaving the month, following the Quartz "W" rule.
+func nearestWeekday(year int, month time.Month, day int) int {
+	monthLength := daysInMonth(year, month)
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == monthLength {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultWorkerCount is the number of workers that execute due runs, and so the number of
+// tasks that can run at the same time.
+const defaultWorkerCount = 64
+
+// queuedRun is the next run of a task, waiting in the run queue.
+type queuedRun struct {
+	task        Task
+	scheduledAt time.Time
+	sequence    uint64 // Insertion order, which breaks ties between runs due at the same time.
+	deferred    bool   // Queued again after waiting for the task's runs in progress; its following run is already queued.
+}
+
+// runQueue is a min-heap of queued runs ordered by run time. It implements heap.Interface.
+type runQueue []*queuedRun
+
+func (queue runQueue) Len() int {
+	return len(queue)
+}
+
+func (queue runQueue) Less(first int, second int) bool {
+	if queue[first].scheduledAt.Equal(queue[second].scheduledAt) {
+		return queue[first].sequence < queue[second].sequence
+	}
+	return queue[first].scheduledAt.Before(queue[second].scheduledAt)
+}
+
+func (queue runQueue) Swap(first int, second int) {
+	queue[first], queue[second] = queue[second], queue[first]
+}
+
+func (queue *runQueue) Push(item interface{}) {
+	*queue = append(*queue, item.(*queuedRun))
+}
+
+func (queue *runQueue) Pop() interface{} {
+	previous := *queue
+	lastIndex := len(previous) - 1
+	item := previous[lastIndex]
+	previous[lastIndex] = nil
+	*queue = previous[:lastIndex]
+	return item
+}
+
+// enqueueNextRunLocked queues the next run of the task after currentTime and wakes the
+// dispatcher. The caller must hold the scheduler mutex.
+func (schedulerInstance *Scheduler) enqueueNextRunLocked(taskInstance Task, currentTime time.Time) {
+	nextRunTime := schedulerInstance.nextRunTime(taskInstance, currentTime)
+	if nextRunTime == nil {
+		schedulerInstance.getLogger().Info("Task will not run again", "task_id", taskInstance.ID())
+		return
+	}
+
+	schedulerInstance.pushRunLocked(&queuedRun{task: taskInstance, scheduledAt: *nextRunTime})
+}
+
+// pushRunLocked adds a run to the run queue and wakes the dispatcher. The caller must hold the
+// scheduler mutex.
+func (schedulerInstance *Scheduler) pushRunLocked(run *queuedRun) {
+	schedulerInstance.runSequence++
+	run.sequence = schedulerInstance.runSequence
+	heap.Push(&schedulerInstance.runQueue, run)
+
+	select {
+	case schedulerInstance.wakeChannel <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch hands due runs to the workers in run-time order, sleeping on a single timer until
+// the earliest queued run is due.
+func (schedulerInstance *Scheduler) dispatch(dueRuns chan<- *queuedRun, stopChannel <-chan struct{}, waitGroup *sync.WaitGroup) {
+	defer waitGroup.Done()
+
+	for {
+		schedulerInstance.mutex.Lock()
+		var dueRun *queuedRun
+		waitDuration := time.Duration(-1)
+		if len(schedulerInstance.runQueue) > 0 {
+			waitDuration = schedulerInstance.runQueue[0].scheduledAt.Sub(schedulerInstance.clock.Now())
+			if waitDuration <= 0 {
+				dueRun = heap.Pop(&schedulerInstance.runQueue).(*queuedRun)
+			}
+		}
+		schedulerInstance.mutex.Unlock()
+
+		if dueRun != nil {
+			// Blocks while every worker is busy; later runs wait their turn in the queue.
+			select {
+			case dueRuns <- dueRun:
+			case <-stopChannel:
+				return
+			}
+			continue
+		}
+
+		// With an empty queue there is no timer, and only a newly queued run or Stop wakes the loop.
+		var timer Timer
+		var timerChannel <-chan time.Time
+		if waitDuration >= 0 {
+			timer = schedulerInstance.clock.NewTimer(waitDuration)
+			timerChannel = timer.C()
+		}
+
+		stopping := false
+		select {
+		case <-timerChannel:
+		case <-schedulerInstance.wakeChannel:
+		case <-stopChannel:
+			stopping = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if stopping {
+			return
+		}
+	}
+}
+
+// work executes due runs. The following run of a repeating schedule is queued as soon as the
+// current one starts, so that it can fall due while the current one is still in progress and
+// the task's overlap policy decides what happens. A one-shot schedule is queued again only once
+// its run has been recorded, which keeps it from being due a second time meanwhile. A deferred
+// run is queued again by the overlap policy, and its following run was queued when it first fell due.
+func (schedulerInstance *Scheduler) work(dueRuns <-chan *queuedRun, runsContext context.Context, stopChannel <-chan struct{}, waitGroup *sync.WaitGroup) {
+	defer waitGroup.Done()
+
+	for {
+		select {
+		case dueRun := <-dueRuns:
+			_, isOneShot := dueRun.task.Schedule().(OneShotSchedule)
+			if !isOneShot && !dueRun.deferred {
+				schedulerInstance.enqueueFollowingRun(dueRun.task, stopChannel)
+			}
+			outcome := schedulerInstance.executeRun(dueRun.task, dueRun.scheduledAt, runsContext, stopChannel)
+			if isOneShot && outcome != runDeferred {
+				schedulerInstance.enqueueFollowingRun(dueRun.task, stopChannel)
+			}
+		case <-stopChannel:
+			return
+		}
+	}
+}
+
+// enqueueFollowingRun queues the task's next run after the current time, unless the Start-Stop
+// cycle that stopChannel belongs to has ended; a restarted scheduler queues the task itself.
+func (schedulerInstance *Scheduler) enqueueFollowingRun(taskInstance Task, stopChannel <-chan struct{}) {
+	schedulerInstance.mutex.Lock()
+	defer schedulerInstance.mutex.Unlock()
+
+	select {
+	case <-stopChannel:
+	default:
+		schedulerInstance.enqueueNextRunLocked(taskInstance, schedulerInstance.clock.Now())
+	}
+}
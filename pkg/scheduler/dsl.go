@@ -0,0 +1,477 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ScheduleSyntaxError reports where ParseSchedule failed to understand its input.
+type ScheduleSyntaxError struct {
+	Input    string
+	Position int // Byte offset of the offending text in Input.
+	Message  string
+	Err      error // Underlying error, such as ErrInvalidCronExpression; may be nil.
+}
+
+// Error returns the message with a 1-based column, such as `column 7: unknown weekday "fry"`.
+func (syntaxError *ScheduleSyntaxError) Error() string {
+	return fmt.Sprintf("%v: %q: column %d: %s", ErrInvalidScheduleSyntax, syntaxError.Input, syntaxError.Position+1, syntaxError.Message)
+}
+
+// Unwrap exposes ErrInvalidScheduleSyntax and the underlying error to errors.Is and errors.As.
+func (syntaxError *ScheduleSyntaxError) Unwrap() []error {
+	if syntaxError.Err != nil {
+		return []error{ErrInvalidScheduleSyntax, syntaxError.Err}
+	}
+	return []error{ErrInvalidScheduleSyntax}
+}
+
+// scheduleToken is a whitespace-separated word of a schedule phrase and its byte offset.
+type scheduleToken struct {
+	text     string
+	position int
+}
+
+// scheduleParser walks the tokens of a schedule phrase.
+type scheduleParser struct {
+	input  string
+	tokens []scheduleToken
+	index  int
+}
+
+var scheduleWeekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// scheduleDateTimeLayouts are the accepted absolute times; the last three are read in the phrase's time zone.
+var scheduleDateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseSchedule parses a human-readable schedule phrase. Keywords are case-insensitive; a phrase
+// may end with a time zone, optionally preceded by "in". Supported forms:
+//
+//	every 15m                        IntervalSchedule
+//	every 15m aligned Europe/Berlin  IntervalSchedule on the quarter hour
+//	every 1h30m starting 10:00       IntervalSchedule counted from 10:00 on January 1, 2000
+//	daily at 09:30 Europe/Berlin     DailySchedule
+//	weekdays at 08:00                WeekdaySchedule (also "weekends", "mon,wed,fri", "mon-fri")
+//	once at 2026-11-01T10:00Z        *OneTimeSchedule
+//	*/5 * * * *                      CronSchedule (also "cron ...", @macros and CRON_TZ=)
+//	OnCalendar=Mon..Fri 09:00        OnCalendarSchedule (also "oncalendar ...")
+//
+// Errors are *ScheduleSyntaxError values that wrap ErrInvalidScheduleSyntax.
+func ParseSchedule(input string) (TimeSchedule, error) {
+	parser := &scheduleParser{input: input, tokens: tokenizeSchedule(input)}
+	if len(parser.tokens) == 0 {
+		return nil, parser.errorAt(len(input), "empty schedule")
+	}
+
+	firstToken := parser.tokens[0]
+	keyword := strings.ToLower(firstToken.text)
+	switch {
+	case keyword == "cron":
+		parser.index++
+		return parser.parseCron()
+	case isCronStart(firstToken.text):
+		return parser.parseCron()
+	case keyword == "oncalendar" || strings.HasPrefix(keyword, "oncalendar="):
+		return parser.parseOnCalendar()
+	case keyword == "every":
+		parser.index++
+		return parser.parseInterval()
+	case keyword == "daily":
+		parser.index++
+		return parser.parseDaily()
+	case keyword == "once":
+		parser.index++
+		return parser.parseOnce()
+	default:
+		return parser.parseWeekdays()
+	}
+}
+
+// intervalAnchorYear is the year of the start time "starting <HH:MM>" gives an interval.
+const intervalAnchorYear = 2000
+
+// parseInterval parses "<duration> [aligned] [starting <time>] [zone]" after "every".
+func (parser *scheduleParser) parseInterval() (TimeSchedule, error) {
+	durationToken, err := parser.expectValue("a duration such as 15m")
+	if err != nil {
+		return nil, err
+	}
+	interval, err := time.ParseDuration(durationToken.text)
+	if err != nil {
+		return nil, parser.errorAt(durationToken.position, fmt.Sprintf("invalid duration %q", durationToken.text))
+	}
+	if interval <= 0 {
+		return nil, parser.errorAt(durationToken.position, "interval must be positive")
+	}
+	aligned := parser.acceptKeyword("aligned")
+	if aligned && interval%time.Second != 0 {
+		return nil, parser.errorAt(durationToken.position, "an aligned interval must be a whole number of seconds")
+	}
+
+	var startTokens []scheduleToken
+	if parser.acceptKeyword("starting") {
+		startTokens, err = parser.dateTimeTokens("a start time such as 10:00")
+		if err != nil {
+			return nil, err
+		}
+	}
+	location, err := parser.parseOptionalZone()
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := IntervalSchedule{Interval: interval}
+	if aligned {
+		schedule.Aligned = true
+		schedule.Location = location
+	}
+	if startTokens != nil {
+		if location == nil {
+			location = time.Local
+		}
+		if hour, minute, isTimeOfDay := parseTimeOfDay(startTokens[0].text); isTimeOfDay && len(startTokens) == 1 {
+			// A fixed date rather than today, so that the same phrase always parses to the same schedule.
+			schedule.StartTime = time.Date(intervalAnchorYear, time.January, 1, hour, minute, 0, 0, location)
+		} else {
+			schedule.StartTime, err = parser.parseDateTime(startTokens, location)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return schedule, nil
+}
+
+// parseDaily parses "at <HH:MM> [zone]" after "daily".
+func (parser *scheduleParser) parseDaily() (TimeSchedule, error) {
+	hour, minute, err := parser.parseAtTimeOfDay()
+	if err != nil {
+		return nil, err
+	}
+	location, err := parser.parseOptionalZone()
+	if err != nil {
+		return nil, err
+	}
+	return DailySchedule{Hour: hour, Minute: minute, Location: location}, nil
+}
+
+// parseWeekdays parses "<days> at <HH:MM> [zone]" where days is "weekdays", "weekends" or
+// a list of day names and ranges such as "mon,wed,fri" or "mon-fri".
+func (parser *scheduleParser) parseWeekdays() (TimeSchedule, error) {
+	firstToken := parser.tokens[0]
+	firstItem, _, _ := strings.Cut(firstToken.text, ",")
+	firstDayName, _, _ := strings.Cut(firstItem, "-")
+	if _, err := parser.parseDayItem(firstDayName, firstToken.position); err != nil {
+		return nil, parser.errorAt(firstToken.position, fmt.Sprintf("unknown schedule %q; expected every, daily, once, weekdays, day names or a cron expression", firstToken.text))
+	}
+
+	var weekdays []time.Weekday
+	for parser.index < len(parser.tokens) && strings.ToLower(parser.tokens[parser.index].text) != "at" {
+		token := parser.tokens[parser.index]
+		parser.index++
+		dayOffset := 0
+		for _, dayText := range strings.Split(token.text, ",") {
+			dayPosition := token.position + dayOffset
+			dayOffset += len(dayText) + 1
+			if dayText == "" {
+				continue
+			}
+			parsedDays, err := parser.parseDayItem(dayText, dayPosition)
+			if err != nil {
+				return nil, err
+			}
+			for _, parsedDay := range parsedDays {
+				if !containsWeekday(weekdays, parsedDay) {
+					weekdays = append(weekdays, parsedDay)
+				}
+			}
+		}
+	}
+	hour, minute, err := parser.parseAtTimeOfDay()
+	if err != nil {
+		return nil, err
+	}
+	location, err := parser.parseOptionalZone()
+	if err != nil {
+		return nil, err
+	}
+	return WeekdaySchedule{Weekdays: weekdays, Hour: hour, Minute: minute, Location: location}, nil
+}
+
+// parseDayItem parses "weekdays", "weekends", a day name or a range of day names such as "mon-fri".
+func (parser *scheduleParser) parseDayItem(dayText string, position int) ([]time.Weekday, error) {
+	lowerText := strings.ToLower(dayText)
+	switch lowerText {
+	case "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, nil
+	case "weekends":
+		return []time.Weekday{time.Saturday, time.Sunday}, nil
+	}
+	firstName, lastName, isRange := strings.Cut(lowerText, "-")
+	firstDay, exists := scheduleWeekdayNames[firstName]
+	if !exists {
+		return nil, parser.errorAt(position, fmt.Sprintf("unknown weekday %q", dayText[:len(firstName)]))
+	}
+	if !isRange {
+		return []time.Weekday{firstDay}, nil
+	}
+	lastDay, exists := scheduleWeekdayNames[lastName]
+	if !exists {
+		return nil, parser.errorAt(position+len(firstName)+1, fmt.Sprintf("unknown weekday %q", dayText[len(firstName)+1:]))
+	}
+	var days []time.Weekday
+	for day := firstDay; ; day = (day + 1) % 7 {
+		days = append(days, day)
+		if day == lastDay {
+			break
+		}
+	}
+	return days, nil
+}
+
+// parseOnce parses "at <date-time> [zone]" after "once".
+func (parser *scheduleParser) parseOnce() (TimeSchedule, error) {
+	if err := parser.expectKeyword("at"); err != nil {
+		return nil, err
+	}
+	dateTimeTokens, err := parser.dateTimeTokens("a date and time such as 2026-11-01T10:00Z")
+	if err != nil {
+		return nil, err
+	}
+	location, err := parser.parseOptionalZone()
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		location = time.Local
+	}
+	executeAt, err := parser.parseDateTime(dateTimeTokens, location)
+	if err != nil {
+		return nil, err
+	}
+	return NewOneTimeSchedule(executeAt), nil
+}
+
+// parseCron parses the remaining tokens as a cron expression.
+func (parser *scheduleParser) parseCron() (TimeSchedule, error) {
+	if parser.index >= len(parser.tokens) {
+		return nil, parser.errorAt(len(parser.input), "expected a cron expression")
+	}
+	expressionStart := parser.tokens[parser.index].position
+	cronSchedule, err := NewCronSchedule(parser.input[expressionStart:])
+	if err != nil {
+		position := expressionStart
+		if fieldIndex := failingCronField(parser.tokens[parser.index:]); fieldIndex >= 0 {
+			position = parser.tokens[parser.index+fieldIndex].position
+		}
+		return nil, &ScheduleSyntaxError{Input: parser.input, Position: position, Message: err.Error(), Err: err}
+	}
+	return cronSchedule, nil
+}
+
+// parseOnCalendar parses a systemd calendar event after "oncalendar" or "OnCalendar=".
+func (parser *scheduleParser) parseOnCalendar() (TimeSchedule, error) {
+	expressionStart := parser.tokens[0].position + len("oncalendar")
+	if strings.HasPrefix(parser.input[expressionStart:], "=") {
+		expressionStart++
+	}
+	schedule, err := NewOnCalendarSchedule(parser.input[expressionStart:])
+	if err != nil {
+		return nil, &ScheduleSyntaxError{Input: parser.input, Position: expressionStart, Message: err.Error(), Err: err}
+	}
+	return schedule, nil
+}
+
+// parseAtTimeOfDay parses "at HH:MM".
+func (parser *scheduleParser) parseAtTimeOfDay() (int, int, error) {
+	if err := parser.expectKeyword("at"); err != nil {
+		return 0, 0, err
+	}
+	timeToken, err := parser.expectValue("a time such as 09:30")
+	if err != nil {
+		return 0, 0, err
+	}
+	hour, minute, isTimeOfDay := parseTimeOfDay(timeToken.text)
+	if !isTimeOfDay {
+		return 0, 0, parser.errorAt(timeToken.position, fmt.Sprintf("invalid time %q; expected HH:MM", timeToken.text))
+	}
+	return hour, minute, nil
+}
+
+// dateTimeTokens consumes a date-time written as one token, or as a date followed by HH:MM.
+func (parser *scheduleParser) dateTimeTokens(expected string) ([]scheduleToken, error) {
+	firstToken, err := parser.expectValue(expected)
+	if err != nil {
+		return nil, err
+	}
+	dateTimeTokens := []scheduleToken{firstToken}
+	if parser.index < len(parser.tokens) {
+		if _, _, isTimeOfDay := parseTimeOfDay(parser.tokens[parser.index].text); isTimeOfDay {
+			dateTimeTokens = append(dateTimeTokens, parser.tokens[parser.index])
+			parser.index++
+		}
+	}
+	return dateTimeTokens, nil
+}
+
+// parseDateTime parses the tokens collected by dateTimeTokens; times without an offset are read in location.
+func (parser *scheduleParser) parseDateTime(dateTimeTokens []scheduleToken, location *time.Location) (time.Time, error) {
+	dateTimeText := dateTimeTokens[0].text
+	if len(dateTimeTokens) == 2 {
+		dateTimeText += "T" + dateTimeTokens[1].text
+	}
+	for _, layout := range scheduleDateTimeLayouts {
+		if parsedTime, err := time.ParseInLocation(layout, strings.ToUpper(dateTimeText), location); err == nil {
+			return parsedTime, nil
+		}
+	}
+	return time.Time{}, parser.errorAt(dateTimeTokens[0].position, fmt.Sprintf("invalid date-time %q; expected a form such as 2026-11-01T10:00Z", dateTimeText))
+}
+
+// parseOptionalZone parses an optional trailing "[in] Zone/Name" and requires the input to end there.
+func (parser *scheduleParser) parseOptionalZone() (*time.Location, error) {
+	if parser.index >= len(parser.tokens) {
+		return nil, nil
+	}
+	hasIn := parser.acceptKeyword("in")
+	zoneToken, err := parser.expectValue("a time zone such as Europe/Berlin")
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(zoneToken.text)
+	if err != nil {
+		message := fmt.Sprintf("unknown time zone %q", zoneToken.text)
+		if !hasIn {
+			message = fmt.Sprintf("unexpected %q; expected a time zone or the end of the schedule", zoneToken.text)
+		}
+		return nil, parser.errorAt(zoneToken.position, message)
+	}
+	if parser.index < len(parser.tokens) {
+		extraToken := parser.tokens[parser.index]
+		return nil, parser.errorAt(extraToken.position, fmt.Sprintf("unexpected %q after the time zone", extraToken.text))
+	}
+	return location, nil
+}
+
+// expectKeyword consumes the given keyword or reports what was found instead.
+func (parser *scheduleParser) expectKeyword(keyword string) error {
+	if parser.acceptKeyword(keyword) {
+		return nil
+	}
+	if parser.index >= len(parser.tokens) {
+		return parser.errorAt(len(parser.input), fmt.Sprintf("expected %q", keyword))
+	}
+	token := parser.tokens[parser.index]
+	return parser.errorAt(token.position, fmt.Sprintf("expected %q, got %q", keyword, token.text))
+}
+
+// acceptKeyword consumes the next token if it is the given keyword.
+func (parser *scheduleParser) acceptKeyword(keyword string) bool {
+	if parser.index < len(parser.tokens) && strings.EqualFold(parser.tokens[parser.index].text, keyword) {
+		parser.index++
+		return true
+	}
+	return false
+}
+
+// expectValue consumes the next token or reports that the input ended early.
+func (parser *scheduleParser) expectValue(expected string) (scheduleToken, error) {
+	if parser.index >= len(parser.tokens) {
+		return scheduleToken{}, parser.errorAt(len(parser.input), "expected "+expected)
+	}
+	token := parser.tokens[parser.index]
+	parser.index++
+	return token, nil
+}
+
+// errorAt builds a ScheduleSyntaxError at the given byte offset.
+func (parser *scheduleParser) errorAt(position int, message string) error {
+	return &ScheduleSyntaxError{Input: parser.input, Position: position, Message: message}
+}
+
+// tokenizeSchedule splits input on whitespace, remembering where each token starts.
+func tokenizeSchedule(input string) []scheduleToken {
+	var tokens []scheduleToken
+	tokenStart := -1
+	for position, character := range input {
+		if unicode.IsSpace(character) {
+			if tokenStart >= 0 {
+				tokens = append(tokens, scheduleToken{text: input[tokenStart:position], position: tokenStart})
+				tokenStart = -1
+			}
+			continue
+		}
+		if tokenStart < 0 {
+			tokenStart = position
+		}
+	}
+	if tokenStart >= 0 {
+		tokens = append(tokens, scheduleToken{text: input[tokenStart:], position: tokenStart})
+	}
+	return tokens
+}
+
+// parseTimeOfDay parses "HH:MM" or "H:MM".
+func parseTimeOfDay(text string) (int, int, bool) {
+	hourText, minuteText, hasColon := strings.Cut(text, ":")
+	if !hasColon || len(hourText) == 0 || len(hourText) > 2 || len(minuteText) != 2 {
+		return 0, 0, false
+	}
+	hour, hourErr := strconv.Atoi(hourText)
+	minute, minuteErr := strconv.Atoi(minuteText)
+	if hourErr != nil || minuteErr != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+// isCronStart reports whether a phrase starting with text is a cron expression rather than a keyword phrase.
+func isCronStart(text string) bool {
+	if strings.HasPrefix(text, "@") || strings.HasPrefix(text, "CRON_TZ=") || strings.HasPrefix(text, "TZ=") {
+		return true
+	}
+	firstCharacter := text[0]
+	return firstCharacter == '*' || firstCharacter == '?' || (firstCharacter >= '0' && firstCharacter <= '9')
+}
+
+// failingCronField returns the index of the first cron field token that does not parse,
+// or -1 when the fields parse individually and the error lies elsewhere.
+func failingCronField(tokens []scheduleToken) int {
+	firstField := 0
+	if len(tokens) > 0 && (strings.HasPrefix(tokens[0].text, "CRON_TZ=") || strings.HasPrefix(tokens[0].text, "TZ=")) {
+		firstField = 1
+	}
+	fieldTokens := tokens[firstField:]
+	var fieldSpecs []cronFieldSpec
+	switch len(fieldTokens) {
+	case 5:
+		fieldSpecs = []cronFieldSpec{cronMinuteSpec, cronHourSpec, cronDayOfMonthSpec, cronMonthSpec, cronDayOfWeekSpec}
+	case 6, 7:
+		fieldSpecs = []cronFieldSpec{cronSecondSpec, cronMinuteSpec, cronHourSpec, cronDayOfMonthSpec, cronMonthSpec, cronQuartzDayOfWeekSpec, cronYearSpec}
+	default:
+		return -1
+	}
+	for index, fieldToken := range fieldTokens {
+		if _, err := parseCronField(fieldToken.text, fieldSpecs[index]); err != nil {
+			return firstField + index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+)
+
+// dstSearchMargin bounds the largest daylight saving shift the wall-clock search accounts for.
+const dstSearchMargin = 3 * time.Hour
+
+// wallClockSearchLimit bounds the number of candidate wall-clock times examined by a single NextRun.
+const wallClockSearchLimit = 100000
+
+// DSTGapPolicy decides what happens to a run whose wall-clock time does not exist because
+// the clocks spring forward over it, such as 02:30 on a day when 02:00 jumps to 03:00.
+type DSTGapPolicy int
+
+const (
+	// DSTGapRunAtNextValidTime runs at the first instant after the gap, 03:00 in the example above.
+	DSTGapRunAtNextValidTime DSTGapPolicy = iota
+	// DSTGapSkip skips the run on that day.
+	DSTGapSkip
+)
+
+// DSTOverlapPolicy decides what happens to a run whose wall-clock time occurs twice because
+// the clocks fall back over it, such as 01:30 on a day when 02:00 returns to 01:00.
+type DSTOverlapPolicy int
+
+const (
+	// DSTOverlapRunOnce runs only at the first occurrence of the ambiguous time.
+	DSTOverlapRunOnce DSTOverlapPolicy = iota
+	// DSTOverlapRunBoth runs at both occurrences of the ambiguous time.
+	DSTOverlapRunBoth
+)
+
+// DSTPolicy controls how calendar schedules handle daylight saving transitions.
+// The zero value runs at the next valid time in a gap and once in an overlap.
+type DSTPolicy struct {
+	Gap     DSTGapPolicy
+	Overlap DSTOverlapPolicy
+}
+
+// wallClockOf returns the wall-clock reading of t, represented in UTC so that
+// arithmetic on it is free of daylight saving effects.
+func wallClockOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// resolveWallClock returns the instants, in ascending order, at which the given wall-clock
+// time occurs in location under policy: none for a skipped gap, two for an overlap run twice.
+func resolveWallClock(wallClock time.Time, location *time.Location, policy DSTPolicy) []time.Time {
+	candidate := time.Date(
+		wallClock.Year(), wallClock.Month(), wallClock.Day(),
+		wallClock.Hour(), wallClock.Minute(), wallClock.Second(), 0, location,
+	)
+
+	if !wallClockOf(candidate).Equal(wallClock) {
+		if policy.Gap == DSTGapSkip {
+			return nil
+		}
+		zoneStart, zoneEnd := candidate.ZoneBounds()
+		if wallClockOf(candidate).After(wallClock) {
+			return []time.Time{zoneStart}
+		}
+		return []time.Time{zoneEnd}
+	}
+
+	instants := []time.Time{candidate}
+	_, currentOffset := candidate.Zone()
+	zoneStart, zoneEnd := candidate.ZoneBounds()
+	if !zoneStart.IsZero() {
+		_, previousOffset := zoneStart.Add(-time.Nanosecond).Zone()
+		alternative := candidate.Add(time.Duration(currentOffset-previousOffset) * time.Second)
+		if alternative.Before(zoneStart) && wallClockOf(alternative).Equal(wallClock) {
+			instants = append(instants, alternative)
+		}
+	}
+	if !zoneEnd.IsZero() {
+		_, nextOffset := zoneEnd.Zone()
+		alternative := candidate.Add(time.Duration(currentOffset-nextOffset) * time.Second)
+		if !alternative.Before(zoneEnd) && wallClockOf(alternative).Equal(wallClock) {
+			instants = append(instants, alternative)
+		}
+	}
+	sort.Slice(instants, func(first, second int) bool {
+		return instants[first].Before(instants[second])
+	})
+
+	if policy.Overlap == DSTOverlapRunOnce {
+		return instants[:1]
+	}
+	return instants
+}
+
+// nextWallClockRun returns the earliest instant strictly after the provided time whose
+// wall-clock reading in location is selected by nextMatch, applying the DST policy.
+// nextMatch must return the earliest matching wall-clock time at or after its argument.
+func nextWallClockRun(after time.Time, location *time.Location, policy DSTPolicy, nextMatch func(wallClock time.Time) (time.Time, bool)) *time.Time {
+	localAfter := after.In(location)
+
+	// Around a fall-back transition, the next run may have an earlier wall-clock reading than after.
+	searchStart := localAfter
+	zoneStart, zoneEnd := localAfter.ZoneBounds()
+	nearZoneStart := !zoneStart.IsZero() && after.Sub(zoneStart) < dstSearchMargin
+	nearZoneEnd := !zoneEnd.IsZero() && zoneEnd.Sub(after) < dstSearchMargin
+	if nearZoneStart || nearZoneEnd {
+		searchStart = localAfter.Add(-dstSearchMargin)
+	}
+	wallClock := wallClockOf(searchStart).Add(time.Second)
+
+	var earliestRun *time.Time
+	var earliestWallClock time.Time
+	for attempt := 0; attempt < wallClockSearchLimit; attempt++ {
+		matchedWallClock, found := nextMatch(wallClock)
+		if !found {
+			break
+		}
+		if earliestRun != nil && matchedWallClock.Sub(earliestWallClock) > dstSearchMargin {
+			break
+		}
+		for _, instant := range resolveWallClock(matchedWallClock, location, policy) {
+			if instant.After(after) && (earliestRun == nil || instant.Before(*earliestRun)) {
+				runTime := instant.In(location)
+				earliestRun = &runTime
+				earliestWallClock = matchedWallClock
+			}
+		}
+		// Without a nearby transition, wall-clock order equals instant order and the first hit wins.
+		if earliestRun != nil && searchStart.Equal(localAfter) && (zoneEnd.IsZero() || zoneEnd.After(earliestRun.Add(dstSearchMargin))) {
+			break
+		}
+		wallClock = matchedWallClock.Add(time.Second)
+	}
+	return earliestRun
+}
@@ -3,6 +3,19 @@ package scheduler
 import "errors"
 
 var (
-	ErrTaskAlreadyExists = errors.New("task with this ID already exists")
-	ErrTaskNotFound      = errors.New("task not found")
+	ErrTaskAlreadyExists     = errors.New("task with this ID already exists")
+	ErrTaskNotFound          = errors.New("task not found")
+	ErrInvalidCronExpression = errors.New("invalid cron expression")
+	ErrInvalidRRule          = errors.New("invalid recurrence rule")
+	ErrInvalidICalendar      = errors.New("invalid iCalendar data")
+	ErrInvalidHolidayList    = errors.New("invalid holiday list")
+	ErrInvalidScheduleSyntax = errors.New("invalid schedule syntax")
+	ErrUnknownScheduleType   = errors.New("unknown schedule type")
+	ErrScheduleTypeExists    = errors.New("schedule type already registered")
+	ErrInvalidScheduleData   = errors.New("invalid schedule data")
+	ErrInvalidSchedule       = errors.New("invalid schedule")
+	ErrInvalidOnCalendar     = errors.New("invalid OnCalendar expression")
+	ErrTaskTimeout           = errors.New("task timed out")
+	ErrShutdownTimeout       = errors.New("scheduler shutdown timed out")
+	ErrRunSkipped            = errors.New("run skipped while another run is in progress")
 )
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock for tests whose time only moves when Advance or Set is called. Timers
+// fire as soon as the clock reaches their deadline, so schedule-driven behavior can be tested
+// without waiting in real time.
+type FakeClock struct {
+	mutex         sync.Mutex
+	currentTime   time.Time
+	timers        []*fakeTimer
+	timersChanged chan struct{} // Closed and replaced whenever a timer is added or removed.
+}
+
+// NewFakeClock creates a FakeClock set to startTime.
+func NewFakeClock(startTime time.Time) *FakeClock {
+	return &FakeClock{currentTime: startTime, timersChanged: make(chan struct{})}
+}
+
+// Now returns the fake current time.
+func (fake *FakeClock) Now() time.Time {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	return fake.currentTime
+}
+
+// NewTimer creates a timer that fires once the clock has been advanced by duration.
+func (fake *FakeClock) NewTimer(duration time.Duration) Timer {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	timer := &fakeTimer{clock: fake, deadline: fake.currentTime.Add(duration), channel: make(chan time.Time, 1)}
+	if duration <= 0 {
+		timer.channel <- fake.currentTime
+		return timer
+	}
+	fake.timers = append(fake.timers, timer)
+	fake.notifyTimersChangedLocked()
+	return timer
+}
+
+// After returns a channel that receives the time once the clock has been advanced by duration.
+func (fake *FakeClock) After(duration time.Duration) <-chan time.Time {
+	return fake.NewTimer(duration).C()
+}
+
+// Sleep blocks until the clock has been advanced by duration.
+func (fake *FakeClock) Sleep(duration time.Duration) {
+	<-fake.After(duration)
+}
+
+// Advance moves the clock forward by duration and fires the timers that fall due, in deadline order.
+func (fake *FakeClock) Advance(duration time.Duration) {
+	fake.Set(fake.Now().Add(duration))
+}
+
+// Set moves the clock to newTime and fires the timers that fall due, in deadline order.
+// Moving the clock backwards fires nothing.
+func (fake *FakeClock) Set(newTime time.Time) {
+	fake.mutex.Lock()
+	fake.currentTime = newTime
+	var dueTimers []*fakeTimer
+	pendingTimers := fake.timers[:0]
+	for _, timer := range fake.timers {
+		if timer.deadline.After(newTime) {
+			pendingTimers = append(pendingTimers, timer)
+		} else {
+			dueTimers = append(dueTimers, timer)
+		}
+	}
+	fake.timers = pendingTimers
+	if len(dueTimers) > 0 {
+		fake.notifyTimersChangedLocked()
+	}
+	fake.mutex.Unlock()
+
+	sort.SliceStable(dueTimers, func(first, second int) bool {
+		return dueTimers[first].deadline.Before(dueTimers[second].deadline)
+	})
+	for _, timer := range dueTimers {
+		timer.channel <- timer.deadline
+	}
+}
+
+// PendingTimers returns the deadlines of the timers that have not fired or been stopped, earliest first.
+func (fake *FakeClock) PendingTimers() []time.Time {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	deadlines := make([]time.Time, len(fake.timers))
+	for index, timer := range fake.timers {
+		deadlines[index] = timer.deadline
+	}
+	sort.Slice(deadlines, func(first, second int) bool {
+		return deadlines[first].Before(deadlines[second])
+	})
+	return deadlines
+}
+
+// WaitForTimers waits until at least count timers are pending, so a test can advance the clock
+// once the code under test is waiting on it. It returns false if the context expires first.
+func (fake *FakeClock) WaitForTimers(ctx context.Context, count int) bool {
+	for {
+		fake.mutex.Lock()
+		pendingCount := len(fake.timers)
+		timersChanged := fake.timersChanged
+		fake.mutex.Unlock()
+
+		if pendingCount >= count {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timersChanged:
+		}
+	}
+}
+
+// notifyTimersChangedLocked wakes WaitForTimers callers. The caller must hold the mutex.
+func (fake *FakeClock) notifyTimersChangedLocked() {
+	close(fake.timersChanged)
+	fake.timersChanged = make(chan struct{})
+}
+
+// fakeTimer is a Timer created by a FakeClock.
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	channel  chan time.Time
+}
+
+func (timer *fakeTimer) C() <-chan time.Time {
+	return timer.channel
+}
+
+func (timer *fakeTimer) Stop() bool {
+	fake := timer.clock
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	for index, pendingTimer := range fake.timers {
+		if pendingTimer == timer {
+			fake.timers = append(fake.timers[:index], fake.timers[index+1:]...)
+			fake.notifyTimersChangedLocked()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FiscalCalendar describes how a fiscal year is divided into quarters and periods. By default
+// periods are calendar months; with WeekPattern they are whole weeks, as in the 4-4-5 calendars
+// used in retail. Fiscal years are named after the calendar year in which they end, so with
+// StartMonth April, FY2027 runs from April 2026 to March 2027.
+type FiscalCalendar struct {
+	StartMonth time.Month // Month in which the fiscal year starts; zero means January.
+	// WeekPattern holds the number of weeks in each of the three periods of a quarter, such as
+	// {4, 4, 5}. Empty means calendar months.
+	WeekPattern []int
+	// YearEndWeekday is the weekday on which a week-based fiscal year ends: the last one in the
+	// month before StartMonth, or the one nearest that month's end with YearEndNearest. The extra
+	// week of a 53-week year goes to the last period.
+	YearEndWeekday time.Weekday
+	YearEndNearest bool
+}
+
+// fiscalPeriod is one period of a fiscal year, with inclusive first and last civil dates.
+type fiscalPeriod struct {
+	first time.Time
+	last  time.Time
+}
+
+// isWeekBased reports whether the calendar uses week-based periods.
+func (calendar FiscalCalendar) isWeekBased() bool {
+	return len(calendar.WeekPattern) > 0
+}
+
+// startMonth returns the month in which fiscal years start.
+func (calendar FiscalCalendar) startMonth() time.Month {
+	if calendar.StartMonth == 0 {
+		return time.January
+	}
+	return calendar.StartMonth
+}
+
+// yearName returns the number of the fiscal year that starts in the given calendar year.
+func (calendar FiscalCalendar) yearName(startYear int) int {
+	if calendar.startMonth() == time.January {
+		return startYear
+	}
+	return startYear + 1
+}
+
+// yearEnd returns the last civil date of a week-based fiscal year named fiscalYear.
+func (calendar FiscalCalendar) yearEnd(fiscalYear int) time.Time {
+	endMonth := calendar.startMonth() - 1
+	if endMonth == 0 {
+		endMonth = time.December
+	}
+	monthEnd := time.Date(fiscalYear, endMonth, daysInMonth(fiscalYear, endMonth), 0, 0, 0, 0, time.UTC)
+	daysBack := (int(monthEnd.Weekday()) - int(calendar.YearEndWeekday) + 7) % 7
+	if calendar.YearEndNearest && daysBack > 3 {
+		return monthEnd.AddDate(0, 0, 7-daysBack)
+	}
+	return monthEnd.AddDate(0, 0, -daysBack)
+}
+
+// periods returns the twelve periods of the fiscal year that starts in startYear.
+func (calendar FiscalCalendar) periods(startYear int) []fiscalPeriod {
+	periods := make([]fiscalPeriod, 0, 12)
+	if !calendar.isWeekBased() {
+		for index := 0; index < 12; index++ {
+			first := time.Date(startYear, calendar.startMonth()+time.Month(index), 1, 0, 0, 0, 0, time.UTC)
+			periods = append(periods, fiscalPeriod{first: first, last: first.AddDate(0, 1, -1)})
+		}
+		return periods
+	}
+	fiscalYear := calendar.yearName(startYear)
+	first := calendar.yearEnd(fiscalYear-1).AddDate(0, 0, 1)
+	last := calendar.yearEnd(fiscalYear)
+	for index := 0; index < 12; index++ {
+		periodEnd := first.AddDate(0, 0, 7*calendar.WeekPattern[index%len(calendar.WeekPattern)]-1)
+		if index == 11 {
+			periodEnd = last
+		}
+		periods = append(periods, fiscalPeriod{first: first, last: periodEnd})
+		first = periodEnd.AddDate(0, 0, 1)
+	}
+	return periods
+}
+
+// describe returns a description such as "fiscal year starting April" or
+// "4-4-5 fiscal calendar ending on the last Saturday of January".
+func (calendar FiscalCalendar) describe() string {
+	if !calendar.isWeekBased() {
+		return "fiscal year starting " + calendar.startMonth().String()
+	}
+	weekCounts := make([]string, len(calendar.WeekPattern))
+	for index, weeks := range calendar.WeekPattern {
+		weekCounts[index] = strconv.Itoa(weeks)
+	}
+	endMonth := calendar.startMonth() - 1
+	if endMonth == 0 {
+		endMonth = time.December
+	}
+	rule := "the last"
+	if calendar.YearEndNearest {
+		rule = "the nearest"
+	}
+	return fmt.Sprintf("%s fiscal calendar ending on %s %s of %s", strings.Join(weekCounts, "-"), rule, calendar.YearEndWeekday, endMonth)
+}
+
+// validate checks the start month, week pattern and year-end weekday.
+func (calendar FiscalCalendar) validate(scheduleType string) error {
+	if calendar.StartMonth < 0 || calendar.StartMonth > time.December {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: "Calendar.StartMonth", Reason: fmt.Sprintf("must be between 1 and 12, got %d", calendar.StartMonth)}
+	}
+	if !calendar.isWeekBased() {
+		return nil
+	}
+	if len(calendar.WeekPattern) != 3 {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: "Calendar.WeekPattern", Reason: fmt.Sprintf("must hold three periods, got %d", len(calendar.WeekPattern))}
+	}
+	totalWeeks := 0
+	for _, weeks := range calendar.WeekPattern {
+		if weeks <= 0 {
+			return &ScheduleFieldError{Schedule: scheduleType, Field: "Calendar.WeekPattern", Reason: fmt.Sprintf("must hold positive week counts, got %d", weeks)}
+		}
+		totalWeeks += weeks
+	}
+	if totalWeeks != 13 {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: "Calendar.WeekPattern", Reason: fmt.Sprintf("must add up to 13 weeks, got %d", totalWeeks)}
+	}
+	if calendar.YearEndWeekday < time.Sunday || calendar.YearEndWeekday > time.Saturday {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: "Calendar.YearEndWeekday", Reason: fmt.Sprintf("holds invalid weekday %d", calendar.YearEndWeekday)}
+	}
+	return nil
+}
+
+// FiscalEvent is a boundary of the fiscal calendar.
+type FiscalEvent int
+
+const (
+	// FiscalPeriodEnd is the last day of each period: a month, or a 4- or 5-week period.
+	FiscalPeriodEnd FiscalEvent = iota
+	// FiscalPeriodStart is the first day of each period.
+	FiscalPeriodStart
+	// FiscalQuarterEnd is the last day of each quarter.
+	FiscalQuarterEnd
+	// FiscalQuarterStart is the first day of each quarter.
+	FiscalQuarterStart
+	// FiscalYearEnd is the last day of the fiscal year.
+	FiscalYearEnd
+	// FiscalYearStart is the first day of the fiscal year.
+	FiscalYearStart
+)
+
+// fiscalEventNames maps fiscal events to their descriptions.
+var fiscalEventNames = map[FiscalEvent]string{
+	FiscalPeriodEnd:    "last day of each fiscal period",
+	FiscalPeriodStart:  "first day of each fiscal period",
+	FiscalQuarterEnd:   "last day of each fiscal quarter",
+	FiscalQuarterStart: "first day of each fiscal quarter",
+	FiscalYearEnd:      "last day of the fiscal year",
+	FiscalYearStart:    "first day of the fiscal year",
+}
+
+// String returns a description of the event, such as "last day of each fiscal quarter".
+func (event FiscalEvent) String() string {
+	if name, exists := fiscalEventNames[event]; exists {
+		return name
+	}
+	return fmt.Sprintf("FiscalEvent(%d)", int(event))
+}
+
+// FiscalSchedule runs a task at Hour:Minute on a boundary of a fiscal calendar, such as the last
+// day of each quarter of a fiscal year starting in April, or each 4-4-5 period close.
+type FiscalSchedule struct {
+	Calendar FiscalCalendar
+	Event    FiscalEvent
+	Hour     int
+	Minute   int
+	Location *time.Location // Optional time zone; see WithLocation.
+	DST      DSTPolicy      // Handling of days with a daylight saving transition.
+}
+
+// fiscalOccurrence is one date on which a FiscalSchedule runs, with its place in the fiscal calendar.
+type fiscalOccurrence struct {
+	date       time.Time
+	fiscalYear int
+	quarter    int // 1-4, or 0 for yearly events.
+	period     int // 1-12, or 0 for quarterly and yearly events.
+}
+
+// NextRun returns the next run time after the provided time.
+func (fiscal FiscalSchedule) NextRun(after time.Time) *time.Time {
+	return nextWallClockRun(after, resolveLocation(fiscal.Location, after), fiscal.DST, func(wallClock time.Time) (time.Time, bool) {
+		for _, occurrence := range fiscal.occurrences(wallClock.Year() - 1) {
+			candidate := time.Date(occurrence.date.Year(), occurrence.date.Month(), occurrence.date.Day(), fiscal.Hour, fiscal.Minute, 0, 0, time.UTC)
+			if !candidate.Before(wallClock) {
+				return candidate, true
+			}
+		}
+		return time.Time{}, false
+	})
+}
+
+// ExplainNextRun names the fiscal period of the next run, such as "Q3 FY2027" or "P7 FY2027".
+func (fiscal FiscalSchedule) ExplainNextRun(after time.Time) string {
+	nextRunTime := fiscal.NextRun(after)
+	if nextRunTime == nil {
+		return ""
+	}
+	runDate := civilDate(nextRunTime.In(resolveLocation(fiscal.Location, *nextRunTime)))
+	for _, occurrence := range fiscal.occurrences(runDate.Year() - 1) {
+		if !occurrence.date.Equal(runDate) {
+			continue
+		}
+		switch {
+		case occurrence.period > 0:
+			return fmt.Sprintf("P%d FY%d", occurrence.period, occurrence.fiscalYear)
+		case occurrence.quarter > 0:
+			return fmt.Sprintf("Q%d FY%d", occurrence.quarter, occurrence.fiscalYear)
+		default:
+			return fmt.Sprintf("FY%d", occurrence.fiscalYear)
+		}
+	}
+	return ""
+}
+
+// occurrences returns the dates of the event in order, for fiscal years starting in firstStartYear
+// and the two years after it.
+func (fiscal FiscalSchedule) occurrences(firstStartYear int) []fiscalOccurrence {
+	var occurrences []fiscalOccurrence
+	for startYear := firstStartYear; startYear < firstStartYear+3; startYear++ {
+		fiscalYear := fiscal.Calendar.yearName(startYear)
+		periods := fiscal.Calendar.periods(startYear)
+		switch fiscal.Event {
+		case FiscalPeriodEnd, FiscalPeriodStart:
+			for index, period := range periods {
+				date := period.last
+				if fiscal.Event == FiscalPeriodStart {
+					date = period.first
+				}
+				occurrences = append(occurrences, fiscalOccurrence{date: date, fiscalYear: fiscalYear, quarter: index/3 + 1, period: index + 1})
+			}
+		case FiscalQuarterEnd, FiscalQuarterStart:
+			for quarter := 1; quarter <= 4; quarter++ {
+				date := periods[quarter*3-1].last
+				if fiscal.Event == FiscalQuarterStart {
+					date = periods[quarter*3-3].first
+				}
+				occurrences = append(occurrences, fiscalOccurrence{date: date, fiscalYear: fiscalYear, quarter: quarter})
+			}
+		case FiscalYearEnd:
+			occurrences = append(occurrences, fiscalOccurrence{date: periods[11].last, fiscalYear: fiscalYear})
+		case FiscalYearStart:
+			occurrences = append(occurrences, fiscalOccurrence{date: periods[0].first, fiscalYear: fiscalYear})
+		}
+	}
+	return occurrences
+}
+
+// Description returns a description such as
+// "At 18:00 on the last day of each fiscal quarter (fiscal year starting April)".
+func (fiscal FiscalSchedule) Description() string {
+	return fmt.Sprintf("At %02d:%02d on the %s (%s)", fiscal.Hour, fiscal.Minute, fiscal.Event, fiscal.Calendar.describe()) +
+		describeLocation(fiscal.Location)
+}
+
+// Validate checks the calendar, the event and the time of day.
+func (fiscal FiscalSchedule) Validate() error {
+	if err := fiscal.Calendar.validate("FiscalSchedule"); err != nil {
+		return err
+	}
+	if _, exists := fiscalEventNames[fiscal.Event]; !exists {
+		return &ScheduleFieldError{Schedule: "FiscalSchedule", Field: "Event", Reason: fmt.Sprintf("holds unknown event %d", fiscal.Event)}
+	}
+	return validateTimeOfDay("FiscalSchedule", fiscal.Hour, fiscal.Minute)
+}
@@ -0,0 +1,291 @@
+package scheduler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ICalendarEvent is a VEVENT read from an iCalendar file.
+type ICalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Schedule    RRuleSchedule
+}
+
+// TaskID returns the identifier used when the event is registered as a task: its UID,
+// or its summary when the event has no UID.
+func (event ICalendarEvent) TaskID() string {
+	if event.UID != "" {
+		return event.UID
+	}
+	return event.Summary
+}
+
+// icalendarProperty is a single unfolded content line such as "DTSTART;TZID=Europe/Berlin:20260105T090000".
+type icalendarProperty struct {
+	name       string
+	parameters map[string]string
+	value      string
+	lineNumber int
+}
+
+// LoadICalendarFile reads the VEVENT entries of a local .ics file.
+func LoadICalendarFile(path string) ([]ICalendarEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseICalendar(file)
+}
+
+// ParseICalendar reads the VEVENT entries of an iCalendar stream. Each event's DTSTART,
+// RRULE, EXDATE and RDATE properties are turned into an RRuleSchedule. TZID parameters
+// must name IANA time zones; VTIMEZONE definitions are ignored.
+func ParseICalendar(reader io.Reader) ([]ICalendarEvent, error) {
+	properties, err := readICalendarProperties(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ICalendarEvent
+	var eventProperties []icalendarProperty
+	insideEvent := false
+	for _, property := range properties {
+		switch {
+		case property.name == "BEGIN" && strings.EqualFold(property.value, "VEVENT"):
+			if insideEvent {
+				return nil, icalendarError(property, "nested VEVENT")
+			}
+			insideEvent = true
+			eventProperties = nil
+		case property.name == "END" && strings.EqualFold(property.value, "VEVENT"):
+			if !insideEvent {
+				return nil, icalendarError(property, "END:VEVENT without BEGIN:VEVENT")
+			}
+			event, err := buildICalendarEvent(eventProperties, property)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+			insideEvent = false
+		case insideEvent:
+			eventProperties = append(eventProperties, property)
+		}
+	}
+	if insideEvent {
+		return nil, fmt.Errorf("%w: unterminated VEVENT", ErrInvalidICalendar)
+	}
+	return events, nil
+}
+
+// RegisterICalendarTasks registers one task per VEVENT in the .ics file at path. The task ID is
+// the event's TaskID, its description is the event summary and its schedule is the event
+// schedule. newTask creates the task for an event; its Schedule method should return event.Schedule.
+func RegisterICalendarTasks(path string, newTask func(event ICalendarEvent) Task) error {
+	events, err := LoadICalendarFile(path)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		calendarEvent := event
+		err := RegisterTask(calendarEvent.TaskID(), calendarEvent.Summary, calendarEvent.Schedule, func() Task {
+			return newTask(calendarEvent)
+		})
+		if err != nil {
+			return fmt.Errorf("registering event %q: %w", calendarEvent.TaskID(), err)
+		}
+	}
+	return nil
+}
+
+// buildICalendarEvent turns the properties of one VEVENT into an ICalendarEvent.
+func buildICalendarEvent(properties []icalendarProperty, endProperty icalendarProperty) (ICalendarEvent, error) {
+	var event ICalendarEvent
+	var startProperty, ruleProperty *icalendarProperty
+	var exceptionProperties, additionalProperties []icalendarProperty
+	for index := range properties {
+		property := properties[index]
+		switch property.name {
+		case "UID":
+			event.UID = property.value
+		case "SUMMARY":
+			event.Summary = unescapeICalendarText(property.value)
+		case "DESCRIPTION":
+			event.Description = unescapeICalendarText(property.value)
+		case "DTSTART":
+			startProperty = &properties[index]
+		case "RRULE":
+			if ruleProperty != nil {
+				return ICalendarEvent{}, icalendarError(property, "multiple RRULE properties are not supported")
+			}
+			ruleProperty = &properties[index]
+		case "EXDATE":
+			exceptionProperties = append(exceptionProperties, property)
+		case "RDATE":
+			additionalProperties = append(additionalProperties, property)
+		}
+	}
+	if startProperty == nil {
+		return ICalendarEvent{}, icalendarError(endProperty, "VEVENT has no DTSTART")
+	}
+
+	startTimes, err := parseICalendarDateList(*startProperty)
+	if err != nil {
+		return ICalendarEvent{}, err
+	}
+	if len(startTimes) != 1 {
+		return ICalendarEvent{}, icalendarError(*startProperty, "DTSTART must hold a single value")
+	}
+
+	rule := ""
+	ruleLineNumber := startProperty.lineNumber
+	if ruleProperty != nil {
+		rule = ruleProperty.value
+		ruleLineNumber = ruleProperty.lineNumber
+	}
+	schedule, err := NewRRuleSchedule(rule, startTimes[0])
+	if err != nil {
+		return ICalendarEvent{}, fmt.Errorf("%w: line %d: %w", ErrInvalidICalendar, ruleLineNumber, err)
+	}
+	for _, property := range exceptionProperties {
+		exceptionDates, err := parseICalendarDateList(property)
+		if err != nil {
+			return ICalendarEvent{}, err
+		}
+		schedule.ExDates = append(schedule.ExDates, exceptionDates...)
+	}
+	for _, property := range additionalProperties {
+		additionalDates, err := parseICalendarDateList(property)
+		if err != nil {
+			return ICalendarEvent{}, err
+		}
+		schedule.RDates = append(schedule.RDates, additionalDates...)
+	}
+	event.Schedule = schedule
+	return event, nil
+}
+
+// readICalendarProperties splits an iCalendar stream into unfolded content lines.
+func readICalendarProperties(reader io.Reader) ([]icalendarProperty, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var unfoldedLines []string
+	var lineNumbers []int
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(unfoldedLines) > 0 {
+			unfoldedLines[len(unfoldedLines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		unfoldedLines = append(unfoldedLines, line)
+		lineNumbers = append(lineNumbers, lineNumber)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	properties := make([]icalendarProperty, 0, len(unfoldedLines))
+	for index, line := range unfoldedLines {
+		property, err := parseICalendarProperty(line, lineNumbers[index])
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// parseICalendarProperty parses "NAME;PARAM=VALUE;...:VALUE", honoring quoted parameter values.
+func parseICalendarProperty(line string, lineNumber int) (icalendarProperty, error) {
+	property := icalendarProperty{parameters: make(map[string]string), lineNumber: lineNumber}
+	insideQuotes := false
+	separatorIndex := -1
+	for index, character := range line {
+		if character == '"' {
+			insideQuotes = !insideQuotes
+		}
+		if character == ':' && !insideQuotes {
+			separatorIndex = index
+			break
+		}
+	}
+	if separatorIndex < 0 {
+		return icalendarProperty{}, fmt.Errorf("%w: line %d: missing ':' in %q", ErrInvalidICalendar, lineNumber, line)
+	}
+
+	nameAndParameters := strings.Split(line[:separatorIndex], ";")
+	property.name = strings.ToUpper(nameAndParameters[0])
+	property.value = line[separatorIndex+1:]
+	for _, parameterText := range nameAndParameters[1:] {
+		parameterName, parameterValue, _ := strings.Cut(parameterText, "=")
+		property.parameters[strings.ToUpper(parameterName)] = strings.Trim(parameterValue, "\"")
+	}
+	return property, nil
+}
+
+// parseICalendarDateList parses the comma separated DATE or DATE-TIME values of a property.
+func parseICalendarDateList(property icalendarProperty) ([]time.Time, error) {
+	location := time.Local
+	if zoneName, exists := property.parameters["TZID"]; exists {
+		loadedLocation, err := time.LoadLocation(zoneName)
+		if err != nil {
+			return nil, icalendarError(property, fmt.Sprintf("unknown time zone %q", zoneName))
+		}
+		location = loadedLocation
+	}
+	if valueType := property.parameters["VALUE"]; valueType != "" && valueType != "DATE" && valueType != "DATE-TIME" {
+		return nil, icalendarError(property, fmt.Sprintf("VALUE=%s is not supported", valueType))
+	}
+
+	var dates []time.Time
+	for _, valueText := range strings.Split(property.value, ",") {
+		date, err := parseICalendarTime(valueText, location)
+		if err != nil {
+			return nil, icalendarError(property, err.Error())
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
+
+// parseICalendarTime parses an iCalendar DATE ("20260105"), UTC DATE-TIME ("20260105T090000Z")
+// or local DATE-TIME ("20260105T090000") interpreted in location.
+func parseICalendarTime(value string, location *time.Location) (time.Time, error) {
+	trimmedValue := strings.TrimSpace(value)
+	switch {
+	case len(trimmedValue) == 8:
+		return time.ParseInLocation("20060102", trimmedValue, location)
+	case strings.HasSuffix(trimmedValue, "Z"):
+		return time.Parse("20060102T150405Z", trimmedValue)
+	default:
+		parsedTime, err := time.ParseInLocation("20060102T150405", trimmedValue, location)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date-time %q", value)
+		}
+		return parsedTime, nil
+	}
+}
+
+// unescapeICalendarText reverses the TEXT escaping of RFC 5545.
+func unescapeICalendarText(text string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(text)
+}
+
+// icalendarError builds an ErrInvalidICalendar error that names the offending line.
+func icalendarError(property icalendarProperty, reason string) error {
+	return fmt.Errorf("%w: line %d: %s: %s", ErrInvalidICalendar, property.lineNumber, property.name, reason)
+}
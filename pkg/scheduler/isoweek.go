@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// isoWeekSearchDays bounds how many days ISOWeekSchedule.NextRun looks ahead; week 53 can be years away.
+const isoWeekSearchDays = 8 * 366
+
+// ISOWeekSchedule runs a task on selected weekdays of selected ISO 8601 weeks, such as the Monday
+// of week 1. ISO weeks start on Monday, and week 1 is the week containing the year's first Thursday,
+// so it can start in late December; the ISO year of a run may differ from its calendar year.
+type ISOWeekSchedule struct {
+	Weeks    []int          // ISO week numbers 1-53; negative values count back from the last week, -1 being the last. Empty means every week.
+	Weekdays []time.Weekday // Days of the selected weeks on which to run.
+	Hour     int
+	Minute   int
+	Location *time.Location // Optional time zone; see WithLocation.
+	DST      DSTPolicy      // Handling of days with a daylight saving transition.
+}
+
+// NextRun returns the next run time after the provided time.
+func (isoWeek ISOWeekSchedule) NextRun(after time.Time) *time.Time {
+	return nextWallClockRun(after, resolveLocation(isoWeek.Location, after), isoWeek.DST, func(wallClock time.Time) (time.Time, bool) {
+		candidate := nextDailyWallClock(wallClock, isoWeek.Hour, isoWeek.Minute)
+		for dayOffset := 0; dayOffset < isoWeekSearchDays; dayOffset++ {
+			if containsWeekday(isoWeek.Weekdays, candidate.Weekday()) && isoWeek.matchesWeek(candidate) {
+				return candidate, true
+			}
+			candidate = candidate.AddDate(0, 0, 1)
+		}
+		return time.Time{}, false
+	})
+}
+
+// matchesWeek reports whether the ISO week of date is selected.
+func (isoWeek ISOWeekSchedule) matchesWeek(date time.Time) bool {
+	if len(isoWeek.Weeks) == 0 {
+		return true
+	}
+	isoYear, week := date.ISOWeek()
+	weeksInYear := isoWeeksInYear(isoYear)
+	for _, selectedWeek := range isoWeek.Weeks {
+		if selectedWeek < 0 {
+			selectedWeek = weeksInYear + 1 + selectedWeek
+		}
+		if selectedWeek == week {
+			return true
+		}
+	}
+	return false
+}
+
+// Description returns a description such as "At 09:00 on Monday of ISO week 1".
+func (isoWeek ISOWeekSchedule) Description() string {
+	weekdayNames := make([]string, len(isoWeek.Weekdays))
+	for index, weekday := range isoWeek.Weekdays {
+		weekdayNames[index] = weekday.String()
+	}
+	weekPhrase := "every ISO week"
+	if len(isoWeek.Weeks) > 0 {
+		var weekPhrases []string
+		for _, week := range isoWeek.Weeks {
+			switch {
+			case week == -1:
+				weekPhrases = append(weekPhrases, "the last ISO week")
+			case week < 0:
+				weekPhrases = append(weekPhrases, fmt.Sprintf("the %s to last ISO week", ordinal(-week)))
+			default:
+				weekPhrases = append(weekPhrases, fmt.Sprintf("ISO week %d", week))
+			}
+		}
+		weekPhrase = joinWithAnd(weekPhrases)
+	}
+	return fmt.Sprintf("At %02d:%02d on %s of %s", isoWeek.Hour, isoWeek.Minute, strings.Join(weekdayNames, ", "), weekPhrase) +
+		describeLocation(isoWeek.Location)
+}
+
+// Validate checks the weeks, weekdays and time of day.
+func (isoWeek ISOWeekSchedule) Validate() error {
+	for index, week := range isoWeek.Weeks {
+		if week == 0 || week < -53 || week > 53 {
+			return &ScheduleFieldError{Schedule: "ISOWeekSchedule", Field: fmt.Sprintf("Weeks[%d]", index), Reason: fmt.Sprintf("must be between 1 and 53 or -53 and -1, got %d", week)}
+		}
+	}
+	if err := validateWeekdays("ISOWeekSchedule", "Weekdays", isoWeek.Weekdays); err != nil {
+		return err
+	}
+	return validateTimeOfDay("ISOWeekSchedule", isoWeek.Hour, isoWeek.Minute)
+}
+
+// isoWeeksInYear returns 52 or 53, the number of ISO weeks in the ISO year.
+func isoWeeksInYear(isoYear int) int {
+	// December 28 always falls in the last ISO week of its year.
+	_, lastWeek := time.Date(isoYear, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return lastWeek
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"time"
+)
+
+// jitterSearchLimit bounds the number of wrapped runs a jittered schedule examines in a single NextRun.
+const jitterSearchLimit = 100000
+
+// JitterSchedule delays every run of another schedule by a pseudo-random offset in [0, MaxJitter).
+// The offset is derived from Seed and the undelayed run time, so instances with different seeds
+// spread out while each one keeps the same run times across restarts.
+type JitterSchedule struct {
+	Schedule  TimeSchedule
+	MaxJitter time.Duration
+	Seed      string // Use HostSeed for a stable per-instance spread or RandomSeed for a per-process one.
+}
+
+// WithJitter delays the runs of schedule by up to maxJitter, deterministically for the given seed.
+func WithJitter(schedule TimeSchedule, maxJitter time.Duration, seed string) JitterSchedule {
+	return JitterSchedule{Schedule: schedule, MaxJitter: maxJitter, Seed: seed}
+}
+
+// WithRandomJitter delays the runs of schedule by up to maxJitter, with offsets that change on every restart.
+func WithRandomJitter(schedule TimeSchedule, maxJitter time.Duration) JitterSchedule {
+	return JitterSchedule{Schedule: schedule, MaxJitter: maxJitter, Seed: RandomSeed()}
+}
+
+// HostSeed returns a jitter seed unique to the task on this host, such as "nightly-report@web-3".
+func HostSeed(taskID string) string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown-host"
+	}
+	return taskID + "@" + hostname
+}
+
+// RandomSeed returns a new random jitter seed.
+func RandomSeed() string {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(randomBytes)
+}
+
+// NextRun returns the first delayed run after the provided time.
+func (jitter JitterSchedule) NextRun(after time.Time) *time.Time {
+	if jitter.MaxJitter <= 0 {
+		return jitter.Schedule.NextRun(after)
+	}
+	// A run up to MaxJitter before after may still be delayed past it.
+	var earliestRun *time.Time
+	baseRunTime := jitter.Schedule.NextRun(after.Add(-jitter.MaxJitter))
+	for attempt := 0; baseRunTime != nil && attempt < jitterSearchLimit; attempt++ {
+		// Delays are never negative, so later runs cannot come before the earliest one found.
+		if earliestRun != nil && baseRunTime.After(*earliestRun) {
+			break
+		}
+		delayedRunTime := baseRunTime.Add(seededOffset(jitter.Seed, *baseRunTime, jitter.MaxJitter))
+		if delayedRunTime.After(after) && (earliestRun == nil || delayedRunTime.Before(*earliestRun)) {
+			earliestRun = &delayedRunTime
+		}
+		baseRunTime = jitter.Schedule.NextRun(*baseRunTime)
+	}
+	return earliestRun
+}
+
+// Description returns a description of the jittered schedule.
+func (jitter JitterSchedule) Description() string {
+	return fmt.Sprintf("%s, delayed by up to %s", jitter.Schedule.Description(), jitter.MaxJitter)
+}
+
+// Validate checks the wrapped schedule and that MaxJitter is not negative.
+func (jitter JitterSchedule) Validate() error {
+	if err := validateNested("JitterSchedule", "Schedule", jitter.Schedule); err != nil {
+		return err
+	}
+	if jitter.MaxJitter < 0 {
+		return &ScheduleFieldError{Schedule: "JitterSchedule", Field: "MaxJitter", Reason: fmt.Sprintf("must not be negative, got %s", jitter.MaxJitter)}
+	}
+	return nil
+}
+
+// RandomWindowSchedule runs once in every range of a window, at a pseudo-random time derived from
+// Seed and the start of the range, such as once a night somewhere between 01:00 and 05:00.
+type RandomWindowSchedule struct {
+	Window Window
+	Seed   string // Use HostSeed for a stable per-instance spread or RandomSeed for a per-process one.
+}
+
+// RandomTimeWithin returns a schedule that runs once at a seeded random time within every range of window.
+func RandomTimeWithin(window Window, seed string) RandomWindowSchedule {
+	return RandomWindowSchedule{Window: window, Seed: seed}
+}
+
+// NextRun returns the run time of the first window range whose run is after the provided time.
+func (random RandomWindowSchedule) NextRun(after time.Time) *time.Time {
+	searchFrom := after
+	for attempt := 0; attempt < jitterSearchLimit; attempt++ {
+		windowStart, windowEnd, ok := random.Window.Range(searchFrom)
+		if !ok {
+			return nil
+		}
+		runTime := windowStart.Add(seededOffset(random.Seed, windowStart, windowEnd.Sub(windowStart)))
+		if runTime.After(after) {
+			return &runTime
+		}
+		searchFrom = windowEnd
+	}
+	return nil
+}
+
+// Description returns a description of the randomized schedule.
+func (random RandomWindowSchedule) Description() string {
+	return "At a random time " + random.Window.Description()
+}
+
+// Validate checks that the schedule has a valid window.
+func (random RandomWindowSchedule) Validate() error {
+	if random.Window == nil {
+		return &ScheduleFieldError{Schedule: "RandomWindowSchedule", Field: "Window", Reason: "is required"}
+	}
+	return validateWindows("RandomWindowSchedule", []Window{random.Window})
+}
+
+// seededOffset returns a deterministic offset in [0, span) for the given seed and instant,
+// rounded down to whole seconds when span is at least a second.
+func seededOffset(seed string, instant time.Time, span time.Duration) time.Duration {
+	if span <= 0 {
+		return 0
+	}
+	hasher := fnv.New64a()
+	hasher.Write([]byte(seed))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(strconv.FormatInt(instant.UnixNano(), 10)))
+	offset := time.Duration(hasher.Sum64() % uint64(span))
+	if span >= time.Second {
+		offset = offset.Truncate(time.Second)
+	}
+	return offset
+}
@@ -0,0 +1,21 @@
+package scheduler
+
+import "time"
+
+// resolveLocation picks the time zone a calendar schedule evaluates its wall-clock times in:
+// the schedule's own location, or else the location of the reference time. The scheduler passes
+// reference times in the location set with WithLocation.
+func resolveLocation(location *time.Location, referenceTime time.Time) *time.Location {
+	if location != nil {
+		return location
+	}
+	return referenceTime.Location()
+}
+
+// describeLocation returns a " (Zone/Name)" suffix for descriptions when a time zone is configured.
+func describeLocation(location *time.Location) string {
+	if location == nil {
+		return ""
+	}
+	return " (" + location.String() + ")"
+}
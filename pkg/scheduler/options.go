@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"log/slog"
+	"time"
+)
+
+const (
+	// defaultRunTimeout bounds each call to Task.Run unless WithRunTimeout says otherwise.
+	defaultRunTimeout = 30 * time.Minute
+	// defaultBeforeExecuteTimeout bounds each call to Task.BeforeExecute unless WithBeforeExecuteTimeout says otherwise.
+	defaultBeforeExecuteTimeout = 30 * time.Minute
+)
+
+// Option configures a Scheduler created by NewScheduler.
+type Option func(schedulerInstance *Scheduler)
+
+// RunEvent describes a run of a task, scheduled or on demand, for Hooks and ErrorHandler.
+type RunEvent struct {
+	TaskID      string
+	ScheduledAt time.Time // When the run was due; the start time for on-demand runs.
+	OnDemand    bool      // Whether the run was started by RunTaskNow.
+	StartedAt   time.Time
+	FinishedAt  time.Time // Zero in BeforeRun.
+	Attempts    int       // Calls to Run, including retries; zero in BeforeRun and if BeforeExecute failed.
+	Err         error     // Final error; nil in BeforeRun and for successful runs.
+	TimedOut    bool      // Whether Err is a TimeoutError.
+}
+
+// Hooks are callbacks around every run, for example to record metrics. Nil fields are skipped.
+// They are called on the goroutine executing the run, so they should return quickly.
+type Hooks struct {
+	BeforeRun  func(event RunEvent) // Called before BeforeExecute.
+	AfterRun   func(event RunEvent) // Called after the last attempt, whether it succeeded or failed.
+	SkippedRun func(event RunEvent) // Called instead of the other two when the overlap policy skips a run; Err wraps ErrRunSkipped.
+}
+
+// ErrorHandler is called after a run has failed for good, once retries are exhausted.
+type ErrorHandler func(event RunEvent)
+
+// WithClock makes the scheduler read the time and wait on timers from clock, such as a FakeClock
+// in tests. By default it uses the system clock.
+func WithClock(clock Clock) Option {
+	return func(schedulerInstance *Scheduler) {
+		if clock != nil {
+			schedulerInstance.clock = clock
+		}
+	}
+}
+
+// WithLocation sets the time zone in which the schedules of tasks registered on the scheduler
+// evaluate their wall-clock times when they have no Location of their own. By default they use
+// the location of the times read from the scheduler's Clock, which is time.Local for the system clock.
+func WithLocation(location *time.Location) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.location = location
+	}
+}
+
+// WithLogger makes the scheduler log to logger instead of the default slog logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.logger = logger
+	}
+}
+
+// WithRunTimeout bounds each call to Task.Run, 30 minutes by default. Zero disables the timeout.
+func WithRunTimeout(timeout time.Duration) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.runTimeout = timeout
+	}
+}
+
+// WithBeforeExecuteTimeout bounds each call to Task.BeforeExecute, 30 minutes by default.
+// Zero disables the timeout.
+func WithBeforeExecuteTimeout(timeout time.Duration) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.beforeExecuteTimeout = timeout
+	}
+}
+
+// WithMaxConcurrency sets how many scheduled runs can execute at the same time, 64 by default.
+// Runs that fall due while every worker is busy wait in the queue, as do runs waiting for an
+// earlier run of their task under OverlapQueue or OverlapReplace, which hold no worker meanwhile.
+// RunTaskNow runs on the caller's goroutine and is not counted.
+func WithMaxConcurrency(maximumConcurrency int) Option {
+	return func(schedulerInstance *Scheduler) {
+		if maximumConcurrency > 0 {
+			schedulerInstance.workerCount = maximumConcurrency
+		}
+	}
+}
+
+// WithOverlapPolicy sets the overlap policy of tasks that do not implement OverlapPolicyTask.
+// By default a run that is due while another run of the task is in progress waits for it.
+func WithOverlapPolicy(overlapPolicy OverlapPolicy) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.overlapPolicy = overlapPolicy
+	}
+}
+
+// WithErrorHandler sets a handler for runs that fail after all retries, in addition to the error log.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.errorHandler = handler
+	}
+}
+
+// WithHooks adds callbacks around every run. It can be given more than once; hooks are called
+// in the order they were added.
+func WithHooks(hooks Hooks) Option {
+	return func(schedulerInstance *Scheduler) {
+		schedulerInstance.hooks = append(schedulerInstance.hooks, hooks)
+	}
+}
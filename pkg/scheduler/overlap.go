@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// OverlapMode decides what happens to a run of a task, scheduled or started by RunTaskNow, that
+// is due while another run of the same task is still in progress.
+type OverlapMode int
+
+const (
+	// OverlapQueue starts the run once the runs in progress have finished. Only one run waits;
+	// runs that are due while one is already waiting are skipped. A waiting scheduled run does not
+	// hold a worker: it is queued again, ahead of later runs, when the runs in progress finish.
+	OverlapQueue OverlapMode = iota
+	// OverlapForbid skips the run.
+	OverlapForbid
+	// OverlapReplace cancels the contexts of the runs in progress and starts the run once they
+	// have returned. Only one run waits; runs that are due while one is already waiting are skipped.
+	OverlapReplace
+	// OverlapAllow starts the run alongside the runs in progress, up to OverlapPolicy.MaxParallel
+	// runs at a time. Runs beyond that are skipped.
+	OverlapAllow
+)
+
+var overlapModeNames = map[OverlapMode]string{OverlapQueue: "queue", OverlapForbid: "forbid", OverlapReplace: "replace", OverlapAllow: "allow"}
+
+func (mode OverlapMode) String() string {
+	if name, known := overlapModeNames[mode]; known {
+		return name
+	}
+	return fmt.Sprintf("OverlapMode(%d)", int(mode))
+}
+
+// OverlapPolicy controls whether runs of a task may overlap. The zero value queues a run that
+// is due while another one is in progress.
+type OverlapPolicy struct {
+	Mode        OverlapMode
+	MaxParallel int // Runs in progress at once under OverlapAllow; not positive means no limit.
+}
+
+// OverlapPolicyTask is an optional interface for tasks that need an overlap policy other than
+// the scheduler's default.
+type OverlapPolicyTask interface {
+	Task
+	OverlapPolicy() OverlapPolicy
+}
+
+var (
+	// errRunReplaced is the cause with which OverlapReplace cancels the contexts of runs in progress.
+	errRunReplaced = errors.New("run replaced by a newer run")
+	// errRunDeferred reports a scheduled run that waits for the runs in progress without a worker.
+	errRunDeferred = errors.New("run deferred until the runs in progress finish")
+)
+
+// taskRuns holds the runs of one task in progress, which its overlap policy is applied against.
+type taskRuns struct {
+	cancels      map[uint64]context.CancelCauseFunc // Cancel the contexts of the runs, keyed like activeRuns.
+	waiting      bool                               // Whether a run waits for them under OverlapQueue or OverlapReplace.
+	finished     chan struct{}                      // Closed and replaced whenever one of them finishes.
+	deferredRun  *queuedRun                         // The waiting run if it is scheduled; queued again once they finish.
+	deferredStop <-chan struct{}                    // Stop channel of the Start-Stop cycle deferredRun belongs to.
+}
+
+// overlapPolicyFor returns the overlap policy of the task: its own, or the scheduler's default.
+func (schedulerInstance *Scheduler) overlapPolicyFor(taskInstance Task) OverlapPolicy {
+	if overlapTask, hasPolicy := taskInstance.(OverlapPolicyTask); hasPolicy {
+		return overlapTask.OverlapPolicy()
+	}
+	return schedulerInstance.overlapPolicy
+}
+
+// admitRun applies the task's overlap policy to a run about to start. If the policy says to wait
+// for the runs in progress, a run started by RunTaskNow waits on the caller's goroutine, while a
+// scheduled run is deferred: admitRun returns errRunDeferred and queues the run again once they
+// have finished. An admitted run is recorded as in progress, with StartedAt set, and admitRun
+// returns its context, derived from runsContext, and the function to call once it has finished.
+// It returns an error wrapping ErrRunSkipped if the policy skips the run, or the error of
+// runsContext if the scheduler stopped while the run was waiting. A deferred run is dropped if
+// stopChannel, that of the Start-Stop cycle it belongs to, closes before it is queued again.
+func (schedulerInstance *Scheduler) admitRun(runsContext context.Context, taskInstance Task, runEvent *RunEvent, stopChannel <-chan struct{}) (context.Context, func(), error) {
+	overlapPolicy := schedulerInstance.overlapPolicyFor(taskInstance)
+	taskIdentifier := taskInstance.ID()
+
+	schedulerInstance.mutex.Lock()
+	defer schedulerInstance.mutex.Unlock()
+
+	runs, exists := schedulerInstance.taskRuns[taskIdentifier]
+	if !exists {
+		runs = &taskRuns{cancels: make(map[uint64]context.CancelCauseFunc), finished: make(chan struct{})}
+		schedulerInstance.taskRuns[taskIdentifier] = runs
+	}
+	if len(runs.cancels) > 0 {
+		skipError := fmt.Errorf("task %q: %w under overlap policy %s", taskIdentifier, ErrRunSkipped, overlapPolicy.Mode)
+		switch overlapPolicy.Mode {
+		case OverlapForbid:
+			return nil, nil, skipError
+		case OverlapAllow:
+			if overlapPolicy.MaxParallel > 0 && len(runs.cancels) >= overlapPolicy.MaxParallel {
+				return nil, nil, skipError
+			}
+		default:
+			if runs.deferredRun != nil && isClosed(runs.deferredStop) {
+				// Left behind by a stop whose runs ignored cancellation and are still in progress.
+				runs.waiting, runs.deferredRun, runs.deferredStop = false, nil, nil
+			}
+			if runs.waiting {
+				return nil, nil, skipError
+			}
+			runs.waiting = true
+			if overlapPolicy.Mode == OverlapReplace {
+				for _, cancelRun := range runs.cancels {
+					cancelRun(errRunReplaced)
+				}
+			}
+			if !runEvent.OnDemand {
+				runs.deferredRun = &queuedRun{task: taskInstance, scheduledAt: runEvent.ScheduledAt, deferred: true}
+				runs.deferredStop = stopChannel
+				return nil, nil, errRunDeferred
+			}
+			for len(runs.cancels) > 0 {
+				finished := runs.finished
+				schedulerInstance.mutex.Unlock()
+				var waitError error
+				select {
+				case <-finished:
+				case <-runsContext.Done():
+					waitError = runsContext.Err()
+				}
+				schedulerInstance.mutex.Lock()
+				if waitError != nil {
+					runs.waiting = false
+					schedulerInstance.forgetTaskRunsLocked(taskIdentifier, runs)
+					return nil, nil, waitError
+				}
+			}
+			runs.waiting = false
+		}
+	}
+
+	runContext, cancelRun := context.WithCancelCause(runsContext)
+	runEvent.StartedAt = schedulerInstance.clock.Now()
+	schedulerInstance.activeRunSequence++
+	activeRunKey := schedulerInstance.activeRunSequence
+	schedulerInstance.activeRuns[activeRunKey] = *runEvent
+	runs.cancels[activeRunKey] = cancelRun
+
+	finishRun := func() {
+		cancelRun(nil)
+		schedulerInstance.mutex.Lock()
+		defer schedulerInstance.mutex.Unlock()
+
+		delete(schedulerInstance.activeRuns, activeRunKey)
+		delete(runs.cancels, activeRunKey)
+		close(runs.finished)
+		runs.finished = make(chan struct{})
+		if len(runs.cancels) == 0 && runs.deferredRun != nil {
+			if !isClosed(runs.deferredStop) {
+				schedulerInstance.pushRunLocked(runs.deferredRun)
+			}
+			runs.waiting, runs.deferredRun, runs.deferredStop = false, nil, nil
+		}
+		schedulerInstance.forgetTaskRunsLocked(taskIdentifier, runs)
+	}
+	return runContext, finishRun, nil
+}
+
+// forgetTaskRunsLocked drops the record of a task's runs once none is in progress or waiting.
+// The caller must hold the scheduler mutex.
+func (schedulerInstance *Scheduler) forgetTaskRunsLocked(taskIdentifier string, runs *taskRuns) {
+	if len(runs.cancels) == 0 && !runs.waiting {
+		delete(schedulerInstance.taskRuns, taskIdentifier)
+	}
+}
+
+// interruptedOutcome tells whether the context of an admitted run was canceled by a replacing
+// run or by shutdown.
+func interruptedOutcome(runContext context.Context) runOutcome {
+	if errors.Is(context.Cause(runContext), errRunReplaced) {
+		return runReplaced
+	}
+	return runStopped
+}
+
+// interruptionReason tells why the context of an admitted run was canceled.
+func interruptionReason(runContext context.Context) string {
+	if interruptedOutcome(runContext) == runReplaced {
+		return "replaced by a newer run"
+	}
+	return "scheduler shutdown"
+}
+
+// isClosed reports whether channel has been closed.
+func isClosed(channel <-chan struct{}) bool {
+	select {
+	case <-channel:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Preview returns up to n upcoming run times of schedule after from, in order.
+// It stops early when the schedule ends or fails to advance, so a faulty schedule
+// cannot make it loop forever.
+func Preview(schedule TimeSchedule, from time.Time, n int) []time.Time {
+	return PreviewBetween(schedule, from, time.Time{}, n)
+}
+
+// PreviewBetween is like Preview but also stops at the first run after until.
+// A zero until means no end date.
+func PreviewBetween(schedule TimeSchedule, from time.Time, until time.Time, n int) []time.Time {
+	if schedule == nil || n <= 0 {
+		return nil
+	}
+	runTimes := make([]time.Time, 0, n)
+	previousRunTime := from
+	var cursor runCursor
+	for len(runTimes) < n {
+		nextRunTime := nextRunWithCursor(schedule, previousRunTime, &cursor)
+		if nextRunTime == nil {
+			break
+		}
+		if !nextRunTime.After(previousRunTime) {
+			slog.Warn("Schedule did not advance, stopping preview", "schedule", schedule.Description(), "after", previousRunTime, "next_run", *nextRunTime)
+			break
+		}
+		if !until.IsZero() && nextRunTime.After(until) {
+			break
+		}
+		runTimes = append(runTimes, *nextRunTime)
+		previousRunTime = *nextRunTime
+	}
+	return runTimes
+}
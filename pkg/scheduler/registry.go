@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"sync"
 )
 
@@ -17,8 +18,15 @@ var (
 )
 
 // RegisterTaskInfo registers metadata about a task in the registry.
-// Returns ErrTaskAlreadyExists if a task with the same ID already exists.
+// Returns ErrTaskAlreadyExists if a task with the same ID already exists, or an error
+// wrapping ErrInvalidSchedule if the schedule fails validation.
 func RegisterTaskInfo(taskID, description string, schedule TimeSchedule) error {
+	if schedule != nil {
+		if err := ValidateSchedule(schedule); err != nil {
+			return fmt.Errorf("task %q: %w", taskID, err)
+		}
+	}
+
 	registryLock.Lock()
 	defer registryLock.Unlock()
 
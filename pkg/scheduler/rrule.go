@@ -0,0 +1,617 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleSearchPeriods bounds how many recurrence periods NextRun expands before giving up.
+const rruleSearchPeriods = 500000
+
+// rruleSearchYears bounds how far past the query time NextRun looks for an occurrence.
+const rruleSearchYears = 200
+
+// rruleFrequency is the FREQ rule part of a recurrence rule.
+type rruleFrequency int
+
+const (
+	rruleNone rruleFrequency = iota
+	rruleSecondly
+	rruleMinutely
+	rruleHourly
+	rruleDaily
+	rruleWeekly
+	rruleMonthly
+	rruleYearly
+)
+
+var rruleFrequencies = map[string]rruleFrequency{
+	"SECONDLY": rruleSecondly,
+	"MINUTELY": rruleMinutely,
+	"HOURLY":   rruleHourly,
+	"DAILY":    rruleDaily,
+	"WEEKLY":   rruleWeekly,
+	"MONTHLY":  rruleMonthly,
+	"YEARLY":   rruleYearly,
+}
+
+var rruleFrequencyUnits = map[rruleFrequency]string{
+	rruleSecondly: "second",
+	rruleMinutely: "minute",
+	rruleHourly:   "hour",
+	rruleDaily:    "day",
+	rruleWeekly:   "week",
+	rruleMonthly:  "month",
+	rruleYearly:   "year",
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// rruleWeekday is a BYDAY element such as "MO", "2TU" or "-1FR".
+type rruleWeekday struct {
+	weekday time.Weekday
+	ordinal int
+}
+
+// RRuleSchedule runs a task according to an RFC 5545 recurrence rule.
+//
+// The rule supports FREQ (SECONDLY through YEARLY), INTERVAL, COUNT, UNTIL, WKST and the
+// BYMONTH, BYYEARDAY, BYMONTHDAY, BYDAY, BYHOUR, BYMINUTE, BYSECOND and BYSETPOS parts.
+// Occurrences are generated in the time zone of Start, which is the DTSTART of the
+// recurrence and always its first occurrence. ExDates removes occurrences and RDates adds
+// occurrences outside the rule, like the iCalendar EXDATE and RDATE properties.
+type RRuleSchedule struct {
+	Start   time.Time
+	ExDates []time.Time
+	RDates  []time.Time
+
+	rule       string
+	frequency  rruleFrequency
+	interval   int
+	count      int
+	until      time.Time
+	weekStart  time.Weekday
+	byMonth    []int
+	byYearDay  []int
+	byMonthDay []int
+	byDay      []rruleWeekday
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	bySetPos   []int
+}
+
+// NewRRuleSchedule parses an RFC 5545 RRULE value, with or without the "RRULE:" prefix,
+// for a recurrence starting at start. An empty rule yields a schedule that runs only at
+// start and at its RDates.
+func NewRRuleSchedule(rule string, start time.Time) (RRuleSchedule, error) {
+	trimmedRule := strings.TrimSpace(rule)
+	trimmedRule = strings.TrimPrefix(trimmedRule, "RRULE:")
+	schedule := RRuleSchedule{
+		Start:     start,
+		rule:      trimmedRule,
+		interval:  1,
+		weekStart: time.Monday,
+	}
+	if trimmedRule == "" {
+		return schedule, nil
+	}
+
+	seenParts := make(map[string]bool)
+	for _, partText := range strings.Split(trimmedRule, ";") {
+		name, value, hasValue := strings.Cut(partText, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !hasValue || value == "" {
+			return RRuleSchedule{}, fmt.Errorf("%w: rule part %q has no value", ErrInvalidRRule, partText)
+		}
+		if seenParts[name] {
+			return RRuleSchedule{}, fmt.Errorf("%w: rule part %s is repeated", ErrInvalidRRule, name)
+		}
+		seenParts[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			frequency, exists := rruleFrequencies[strings.ToUpper(value)]
+			if !exists {
+				err = fmt.Errorf("unknown frequency %q", value)
+			}
+			schedule.frequency = frequency
+		case "INTERVAL":
+			schedule.interval, err = parseRRulePositive(value)
+		case "COUNT":
+			schedule.count, err = parseRRulePositive(value)
+		case "UNTIL":
+			schedule.until, err = parseICalendarTime(value, start.Location())
+		case "WKST":
+			weekday, exists := rruleWeekdays[strings.ToUpper(value)]
+			if !exists {
+				err = fmt.Errorf("unknown weekday %q", value)
+			}
+			schedule.weekStart = weekday
+		case "BYMONTH":
+			schedule.byMonth, err = parseRRuleNumbers(value, 1, 12, false)
+		case "BYYEARDAY":
+			schedule.byYearDay, err = parseRRuleNumbers(value, 1, 366, true)
+		case "BYMONTHDAY":
+			schedule.byMonthDay, err = parseRRuleNumbers(value, 1, 31, true)
+		case "BYDAY":
+			schedule.byDay, err = parseRRuleWeekdays(value)
+		case "BYHOUR":
+			schedule.byHour, err = parseRRuleNumbers(value, 0, 23, false)
+		case "BYMINUTE":
+			schedule.byMinute, err = parseRRuleNumbers(value, 0, 59, false)
+		case "BYSECOND":
+			schedule.bySecond, err = parseRRuleNumbers(value, 0, 59, false)
+		case "BYSETPOS":
+			schedule.bySetPos, err = parseRRuleNumbers(value, 1, 366, true)
+		default:
+			err = fmt.Errorf("rule part is not supported")
+		}
+		if err != nil {
+			return RRuleSchedule{}, fmt.Errorf("%w: %s=%s: %v", ErrInvalidRRule, name, value, err)
+		}
+	}
+
+	if schedule.frequency == rruleNone {
+		return RRuleSchedule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+	if schedule.count > 0 && !schedule.until.IsZero() {
+		return RRuleSchedule{}, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRRule)
+	}
+	return schedule, nil
+}
+
+// Rule returns the RRULE value the schedule was parsed from.
+func (rrule RRuleSchedule) Rule() string {
+	return rrule.rule
+}
+
+// Validate checks that the schedule was created by NewRRuleSchedule and has a start time.
+func (rrule RRuleSchedule) Validate() error {
+	if rrule.interval == 0 {
+		return &ScheduleFieldError{Schedule: "RRuleSchedule", Field: "rule", Reason: "is not parsed; use NewRRuleSchedule"}
+	}
+	if rrule.Start.IsZero() {
+		return &ScheduleFieldError{Schedule: "RRuleSchedule", Field: "Start", Reason: "must not be zero"}
+	}
+	return nil
+}
+
+// NextRun returns the next run time after the provided time.
+func (rrule RRuleSchedule) NextRun(after time.Time) *time.Time {
+	location := rrule.Start.Location()
+	var nextRunTime *time.Time
+	if rrule.Start.After(after) && !rrule.isExcluded(rrule.Start) {
+		startTime := rrule.Start
+		nextRunTime = &startTime
+	} else if rrule.frequency != rruleNone {
+		nextRunTime = rrule.nextRuleOccurrence(after)
+	}
+	for _, additionalDate := range rrule.RDates {
+		if additionalDate.After(after) && !rrule.isExcluded(additionalDate) &&
+			(nextRunTime == nil || additionalDate.Before(*nextRunTime)) {
+			runTime := additionalDate
+			nextRunTime = &runTime
+		}
+	}
+	if nextRunTime == nil {
+		return nil
+	}
+	runTime := nextRunTime.In(location)
+	return &runTime
+}
+
+// nextRuleOccurrence returns the first occurrence generated by the rule after the provided time.
+func (rrule RRuleSchedule) nextRuleOccurrence(after time.Time) *time.Time {
+	location := rrule.Start.Location()
+	startWallClock := wallClockOf(rrule.Start)
+	afterWallClock := wallClockOf(after.In(location))
+	yearLimit := max(afterWallClock.Year(), startWallClock.Year()) + rruleSearchYears
+
+	// COUNT requires counting every occurrence from the start; otherwise skip ahead.
+	firstPeriod := 0
+	if rrule.count == 0 {
+		firstPeriod = max(0, rrule.periodIndex(afterWallClock)-1)
+	}
+	occurrenceCount := 1 // DTSTART is always the first occurrence.
+
+	for periodIndex := firstPeriod; periodIndex < firstPeriod+rruleSearchPeriods; periodIndex++ {
+		periodStart := rrule.periodStart(periodIndex)
+		if periodStart.Year() > yearLimit {
+			return nil
+		}
+		if !rrule.until.IsZero() && periodStart.After(wallClockOf(rrule.until.In(location))) {
+			return nil
+		}
+		for _, wallClock := range rrule.expandPeriod(periodStart) {
+			if !wallClock.After(startWallClock) {
+				continue
+			}
+			occurrence := time.Date(
+				wallClock.Year(), wallClock.Month(), wallClock.Day(),
+				wallClock.Hour(), wallClock.Minute(), wallClock.Second(), 0, location,
+			)
+			if !rrule.until.IsZero() && occurrence.After(rrule.until) {
+				return nil
+			}
+			occurrenceCount++
+			if rrule.count > 0 && occurrenceCount > rrule.count {
+				return nil
+			}
+			if occurrence.After(after) && !rrule.isExcluded(occurrence) {
+				return &occurrence
+			}
+		}
+	}
+	return nil
+}
+
+// isExcluded reports whether an occurrence is listed in ExDates.
+func (rrule RRuleSchedule) isExcluded(occurrence time.Time) bool {
+	for _, exceptionDate := range rrule.ExDates {
+		if exceptionDate.Equal(occurrence) {
+			return true
+		}
+	}
+	return false
+}
+
+// periodIndex returns the index of the recurrence period containing the wall-clock time.
+func (rrule RRuleSchedule) periodIndex(wallClock time.Time) int {
+	startWallClock := wallClockOf(rrule.Start)
+	var elapsedPeriods int
+	switch rrule.frequency {
+	case rruleYearly:
+		elapsedPeriods = wallClock.Year() - startWallClock.Year()
+	case rruleMonthly:
+		elapsedPeriods = (wallClock.Year()-startWallClock.Year())*12 + int(wallClock.Month()) - int(startWallClock.Month())
+	case rruleWeekly:
+		elapsedPeriods = int(wallClock.Sub(rrule.periodStart(0)).Hours() / (24 * 7))
+	case rruleDaily:
+		elapsedPeriods = int(wallClock.Sub(rrule.periodStart(0)).Hours() / 24)
+	case rruleHourly:
+		elapsedPeriods = int(wallClock.Sub(rrule.periodStart(0)) / time.Hour)
+	case rruleMinutely:
+		elapsedPeriods = int(wallClock.Sub(rrule.periodStart(0)) / time.Minute)
+	case rruleSecondly:
+		elapsedPeriods = int(wallClock.Sub(rrule.periodStart(0)) / time.Second)
+	}
+	return elapsedPeriods / rrule.interval
+}
+
+// periodStart returns the wall-clock start of the recurrence period with the given index.
+func (rrule RRuleSchedule) periodStart(periodIndex int) time.Time {
+	startWallClock := wallClockOf(rrule.Start)
+	step := periodIndex * rrule.interval
+	startDate := time.Date(startWallClock.Year(), startWallClock.Month(), startWallClock.Day(), 0, 0, 0, 0, time.UTC)
+	switch rrule.frequency {
+	case rruleYearly:
+		return time.Date(startWallClock.Year()+step, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case rruleMonthly:
+		return time.Date(startWallClock.Year(), startWallClock.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+	case rruleWeekly:
+		daysSinceWeekStart := (int(startDate.Weekday()) - int(rrule.weekStart) + 7) % 7
+		return startDate.AddDate(0, 0, step*7-daysSinceWeekStart)
+	case rruleDaily:
+		return startDate.AddDate(0, 0, step)
+	case rruleHourly:
+		return startWallClock.Truncate(time.Hour).Add(time.Duration(step) * time.Hour)
+	case rruleMinutely:
+		return startWallClock.Truncate(time.Minute).Add(time.Duration(step) * time.Minute)
+	default:
+		return startWallClock.Add(time.Duration(step) * time.Second)
+	}
+}
+
+// expandPeriod returns the sorted wall-clock occurrences of a single recurrence period.
+func (rrule RRuleSchedule) expandPeriod(periodStart time.Time) []time.Time {
+	var occurrences []time.Time
+	for _, date := range rrule.periodDates(periodStart) {
+		for _, hour := range rrule.timeValues(rrule.byHour, periodStart.Hour(), rrule.Start.Hour(), rruleHourly) {
+			for _, minute := range rrule.timeValues(rrule.byMinute, periodStart.Minute(), rrule.Start.Minute(), rruleMinutely) {
+				for _, second := range rrule.timeValues(rrule.bySecond, periodStart.Second(), rrule.Start.Second(), rruleSecondly) {
+					occurrences = append(occurrences, time.Date(date.Year(), date.Month(), date.Day(), hour, minute, second, 0, time.UTC))
+				}
+			}
+		}
+	}
+	sort.Slice(occurrences, func(first, second int) bool {
+		return occurrences[first].Before(occurrences[second])
+	})
+	if len(rrule.bySetPos) == 0 {
+		return occurrences
+	}
+
+	var selectedOccurrences []time.Time
+	for _, position := range rrule.bySetPos {
+		index := position - 1
+		if position < 0 {
+			index = len(occurrences) + position
+		}
+		if index >= 0 && index < len(occurrences) {
+			selectedOccurrences = append(selectedOccurrences, occurrences[index])
+		}
+	}
+	sort.Slice(selectedOccurrences, func(first, second int) bool {
+		return selectedOccurrences[first].Before(selectedOccurrences[second])
+	})
+	return selectedOccurrences
+}
+
+// timeValues returns the hours, minutes or seconds of a period: the period's own value,
+// limited by the BY list, for frequencies at or below the given unit; otherwise the BY
+// list or, without one, the value of the start time.
+func (rrule RRuleSchedule) timeValues(byValues []int, periodValue int, startValue int, unitFrequency rruleFrequency) []int {
+	if rrule.frequency <= unitFrequency {
+		if len(byValues) > 0 && !containsInt(byValues, periodValue) {
+			return nil
+		}
+		return []int{periodValue}
+	}
+	if len(byValues) > 0 {
+		return byValues
+	}
+	return []int{startValue}
+}
+
+// periodDates returns the dates of a period that satisfy the date rule parts.
+func (rrule RRuleSchedule) periodDates(periodStart time.Time) []time.Time {
+	startWallClock := wallClockOf(rrule.Start)
+	var candidateDates []time.Time
+	switch rrule.frequency {
+	case rruleYearly:
+		hasDayRules := len(rrule.byYearDay) > 0 || len(rrule.byMonthDay) > 0 || len(rrule.byDay) > 0
+		switch {
+		case hasDayRules:
+			for date := periodStart; date.Year() == periodStart.Year(); date = date.AddDate(0, 0, 1) {
+				candidateDates = append(candidateDates, date)
+			}
+		case len(rrule.byMonth) > 0:
+			for _, month := range rrule.byMonth {
+				candidateDates = appendValidDate(candidateDates, periodStart.Year(), time.Month(month), startWallClock.Day())
+			}
+		default:
+			candidateDates = appendValidDate(candidateDates, periodStart.Year(), startWallClock.Month(), startWallClock.Day())
+		}
+	case rruleMonthly:
+		if len(rrule.byMonthDay) > 0 || len(rrule.byDay) > 0 {
+			for date := periodStart; date.Month() == periodStart.Month(); date = date.AddDate(0, 0, 1) {
+				candidateDates = append(candidateDates, date)
+			}
+		} else {
+			candidateDates = appendValidDate(candidateDates, periodStart.Year(), periodStart.Month(), startWallClock.Day())
+		}
+	case rruleWeekly:
+		for dayOffset := 0; dayOffset < 7; dayOffset++ {
+			date := periodStart.AddDate(0, 0, dayOffset)
+			if len(rrule.byDay) > 0 || date.Weekday() == startWallClock.Weekday() {
+				candidateDates = append(candidateDates, date)
+			}
+		}
+	default:
+		candidateDates = append(candidateDates, time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day(), 0, 0, 0, 0, time.UTC))
+	}
+
+	var dates []time.Time
+	for _, date := range candidateDates {
+		if rrule.matchesDate(date) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// matchesDate applies the BYMONTH, BYYEARDAY, BYMONTHDAY and BYDAY filters to a date.
+func (rrule RRuleSchedule) matchesDate(date time.Time) bool {
+	if len(rrule.byMonth) > 0 && !containsInt(rrule.byMonth, int(date.Month())) {
+		return false
+	}
+	if len(rrule.byYearDay) > 0 {
+		yearLength := time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		if !matchesSignedValue(rrule.byYearDay, date.YearDay(), yearLength) {
+			return false
+		}
+	}
+	if len(rrule.byMonthDay) > 0 && !matchesSignedValue(rrule.byMonthDay, date.Day(), daysInMonth(date.Year(), date.Month())) {
+		return false
+	}
+	if len(rrule.byDay) > 0 {
+		// Ordinals count within the year for yearly rules without BYMONTH, otherwise within the month.
+		countWithinYear := rrule.frequency == rruleYearly && len(rrule.byMonth) == 0
+		matched := false
+		for _, weekdayRule := range rrule.byDay {
+			if date.Weekday() != weekdayRule.weekday {
+				continue
+			}
+			if weekdayRule.ordinal == 0 || rrule.frequency < rruleMonthly || rrule.frequency == rruleWeekly {
+				matched = true
+				break
+			}
+			position, positionFromEnd := date.Day(), daysInMonth(date.Year(), date.Month())-date.Day()+1
+			if countWithinYear {
+				yearLength := time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+				position, positionFromEnd = date.YearDay(), yearLength-date.YearDay()+1
+			}
+			if weekdayRule.ordinal > 0 && (position-1)/7+1 == weekdayRule.ordinal ||
+				weekdayRule.ordinal < 0 && (positionFromEnd-1)/7+1 == -weekdayRule.ordinal {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Description returns a description of the recurrence rule.
+func (rrule RRuleSchedule) Description() string {
+	location := rrule.Start.Location()
+	locationSuffix := ""
+	if location != time.Local {
+		locationSuffix = " (" + location.String() + ")"
+	}
+	if rrule.frequency == rruleNone {
+		return "Once at " + rrule.Start.Format("2006-01-02 15:04") + locationSuffix
+	}
+
+	phrases := []string{"Every " + pluralizeUnit(rrule.interval, rruleFrequencyUnits[rrule.frequency])}
+	if len(rrule.byMonth) > 0 {
+		var monthNames []string
+		for _, month := range rrule.byMonth {
+			monthNames = append(monthNames, time.Month(month).String())
+		}
+		phrases = append(phrases, "in "+joinWithAnd(monthNames))
+	}
+	if len(rrule.byYearDay) > 0 {
+		phrases = append(phrases, "on day "+joinWithAnd(formatSignedOrdinals(rrule.byYearDay))+" of the year")
+	}
+	if len(rrule.byMonthDay) > 0 {
+		phrases = append(phrases, "on the "+joinWithAnd(formatSignedOrdinals(rrule.byMonthDay))+" of the month")
+	}
+	if len(rrule.byDay) > 0 {
+		var weekdayPhrases []string
+		for _, weekdayRule := range rrule.byDay {
+			switch {
+			case weekdayRule.ordinal == 0:
+				weekdayPhrases = append(weekdayPhrases, weekdayRule.weekday.String())
+			default:
+				weekdayPhrases = append(weekdayPhrases, fmt.Sprintf("the %s %s", formatSignedOrdinal(weekdayRule.ordinal), weekdayRule.weekday))
+			}
+		}
+		phrases = append(phrases, "on "+joinWithAnd(weekdayPhrases))
+	}
+	if len(rrule.bySetPos) > 0 {
+		phrases = append(phrases, "taking the "+joinWithAnd(formatSignedOrdinals(rrule.bySetPos))+" match")
+	}
+	if rrule.frequency >= rruleDaily {
+		var clockTimes []string
+		for _, hour := range rrule.timeValues(rrule.byHour, 0, rrule.Start.Hour(), rruleHourly) {
+			for _, minute := range rrule.timeValues(rrule.byMinute, 0, rrule.Start.Minute(), rruleMinutely) {
+				clockTimes = append(clockTimes, fmt.Sprintf("%02d:%02d", hour, minute))
+			}
+		}
+		phrases = append(phrases, "at "+joinWithAnd(clockTimes))
+	}
+
+	description := strings.Join(phrases, " ")
+	switch {
+	case rrule.count > 0:
+		description += fmt.Sprintf(", %s", pluralizeUnit(rrule.count, "time"))
+	case !rrule.until.IsZero():
+		description += ", until " + rrule.until.In(location).Format("2006-01-02 15:04")
+	}
+	return description + ", starting " + rrule.Start.Format("2006-01-02") + locationSuffix
+}
+
+// parseRRulePositive parses a positive integer rule value.
+func parseRRulePositive(value string) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("must be a positive number")
+	}
+	return number, nil
+}
+
+// parseRRuleNumbers parses a comma separated list of numbers whose absolute values lie
+// between minimum and maximum; negative values are accepted only when allowNegative is set.
+func parseRRuleNumbers(value string, minimum int, maximum int, allowNegative bool) ([]int, error) {
+	var numbers []int
+	for _, numberText := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(strings.TrimPrefix(numberText, "+"))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", numberText)
+		}
+		magnitude := number
+		if number < 0 && allowNegative {
+			magnitude = -number
+		}
+		if magnitude < minimum || magnitude > maximum {
+			return nil, fmt.Errorf("%d is out of range", number)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// parseRRuleWeekdays parses a BYDAY list such as "MO,WE,FR" or "2TU,-1FR".
+func parseRRuleWeekdays(value string) ([]rruleWeekday, error) {
+	var weekdayRules []rruleWeekday
+	for _, itemText := range strings.Split(strings.ToUpper(value), ",") {
+		if len(itemText) < 2 {
+			return nil, fmt.Errorf("%q is not a weekday", itemText)
+		}
+		weekday, exists := rruleWeekdays[itemText[len(itemText)-2:]]
+		if !exists {
+			return nil, fmt.Errorf("%q is not a weekday", itemText)
+		}
+		weekdayRule := rruleWeekday{weekday: weekday}
+		if ordinalText := itemText[:len(itemText)-2]; ordinalText != "" {
+			ordinalValue, err := strconv.Atoi(strings.TrimPrefix(ordinalText, "+"))
+			if err != nil || ordinalValue == 0 || ordinalValue < -53 || ordinalValue > 53 {
+				return nil, fmt.Errorf("%q has an invalid ordinal", itemText)
+			}
+			weekdayRule.ordinal = ordinalValue
+		}
+		weekdayRules = append(weekdayRules, weekdayRule)
+	}
+	return weekdayRules, nil
+}
+
+// appendValidDate appends the date if the day exists in the month.
+func appendValidDate(dates []time.Time, year int, month time.Month, day int) []time.Time {
+	if day > daysInMonth(year, month) {
+		return dates
+	}
+	return append(dates, time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// matchesSignedValue reports whether value, or its position counted from the end of a
+// range of the given length, appears in the list of signed values.
+func matchesSignedValue(signedValues []int, value int, length int) bool {
+	for _, signedValue := range signedValues {
+		if signedValue == value || signedValue < 0 && length+1+signedValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsInt checks if target is in the list of values.
+func containsInt(values []int, target int) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSignedOrdinal formats 2 as "2nd", -1 as "last" and -2 as "2nd to last".
+func formatSignedOrdinal(number int) string {
+	switch {
+	case number == -1:
+		return "last"
+	case number < 0:
+		return ordinal(-number) + " to last"
+	default:
+		return ordinal(number)
+	}
+}
+
+// formatSignedOrdinals applies formatSignedOrdinal to each number.
+func formatSignedOrdinals(numbers []int) []string {
+	var formattedNumbers []string
+	for _, number := range numbers {
+		formattedNumbers = append(formattedNumbers, formatSignedOrdinal(number))
+	}
+	return formattedNumbers
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -17,6 +18,28 @@ type TimeSchedule interface {
 	Description() string
 }
 
+// NextRunExplainer is an optional interface for schedules whose next run can differ from the
+// plain rule, such as a run moved off a holiday. The explanation is shown by --list.
+type NextRunExplainer interface {
+	// ExplainNextRun returns a short note about the next run after the provided time, or "" if there is none.
+	ExplainNextRun(afterTime time.Time) string
+}
+
+// ExecutionObserver is an optional interface for schedules that want to be notified after the
+// scheduler has executed one of their runs, whether it succeeded or failed.
+type ExecutionObserver interface {
+	// AfterExecution is called with the time the run was scheduled for and the final error, if any.
+	AfterExecution(scheduledAt time.Time, err error)
+}
+
+// OneShotSchedule is an optional interface for schedules that run exactly once. The scheduler
+// records the run in its CompletionStore and runs it immediately if it is overdue and not
+// yet completed, for example because the process was down at the scheduled time.
+type OneShotSchedule interface {
+	TimeSchedule
+	RunTime() time.Time
+}
+
 // Task defines the interface that every task must implement.
 type Task interface {
 	ID() string
@@ -27,40 +50,87 @@ type Task interface {
 	RetryDelay(attempt int) time.Duration
 }
 
-// Scheduler manages the registration and execution of tasks.
+// Scheduler manages the registration and execution of tasks. A single dispatcher keeps the
+// next run of every task in a queue ordered by run time and hands due runs to a pool of workers.
 type Scheduler struct {
-	tasks       map[string]Task
-	isRunning   bool
-	stopChannel chan struct{}
-	waitGroup   sync.WaitGroup
-	mutex       sync.Mutex
+	tasks                map[string]Task
+	completionStore      CompletionStore
+	clock                Clock
+	logger               *slog.Logger
+	runTimeout           time.Duration
+	beforeExecuteTimeout time.Duration
+	overlapPolicy        OverlapPolicy
+	location             *time.Location // Time zone of schedules without their own; see WithLocation.
+	errorHandler         ErrorHandler
+	hooks                []Hooks
+	runQueue             runQueue
+	runSequence          uint64
+	wakeChannel          chan struct{}
+	workerCount          int
+	isRunning            bool
+	stopChannel          chan struct{}       // Closed when the scheduler stops dispatching runs.
+	runsContext          context.Context     // Parent of the contexts of runs; canceled to interrupt them.
+	cancelRuns           context.CancelFunc  // Cancels runsContext.
+	activeRuns           map[uint64]RunEvent // Runs in progress, keyed by the order they started in.
+	activeRunSequence    uint64
+	taskRuns             map[string]*taskRuns  // Runs in progress or waiting, by task, for overlap policies.
+	runCursors           map[string]*runCursor // How far the MaxRuns of each task's BoundedSchedule have been counted.
+	waitGroup            *sync.WaitGroup       // Goroutines and runs of the current Start-Stop cycle; Start replaces it.
+	mutex                sync.Mutex
 }
 
-// NewScheduler creates a new Scheduler instance.
-func NewScheduler() *Scheduler {
-	return &Scheduler{
-		tasks:       make(map[string]Task),
-		stopChannel: make(chan struct{}),
+// NewScheduler creates a new Scheduler instance configured by the given options.
+func NewScheduler(options ...Option) *Scheduler {
+	schedulerInstance := &Scheduler{
+		tasks:                make(map[string]Task),
+		completionStore:      NewMemoryCompletionStore(),
+		clock:                systemClock{},
+		runTimeout:           defaultRunTimeout,
+		beforeExecuteTimeout: defaultBeforeExecuteTimeout,
+		wakeChannel:          make(chan struct{}, 1),
+		workerCount:          defaultWorkerCount,
+		stopChannel:          make(chan struct{}),
+		waitGroup:            &sync.WaitGroup{},
+		activeRuns:           make(map[uint64]RunEvent),
+		taskRuns:             make(map[string]*taskRuns),
+		runCursors:           make(map[string]*runCursor),
 	}
+	for _, option := range options {
+		option(schedulerInstance)
+	}
+	return schedulerInstance
+}
+
+// SetCompletionStore replaces the store that records completed one-shot runs, for example
+// with a FileCompletionStore so that they are not repeated after a restart.
+func (schedulerInstance *Scheduler) SetCompletionStore(store CompletionStore) {
+	schedulerInstance.mutex.Lock()
+	defer schedulerInstance.mutex.Unlock()
+
+	schedulerInstance.completionStore = store
 }
 
 // RegisterTask registers a new task for execution.
+// It returns an error wrapping ErrInvalidSchedule if the task's schedule is nil or fails validation.
 func (schedulerInstance *Scheduler) RegisterTask(newTask Task) error {
+	taskIdentifier := newTask.ID()
+	if err := ValidateSchedule(newTask.Schedule()); err != nil {
+		return fmt.Errorf("task %q: %w", taskIdentifier, err)
+	}
+
 	schedulerInstance.mutex.Lock()
 	defer schedulerInstance.mutex.Unlock()
 
-	taskIdentifier := newTask.ID()
 	if _, exists := schedulerInstance.tasks[taskIdentifier]; exists {
 		return fmt.Errorf("task with ID %q already exists", taskIdentifier)
 	}
 
 	schedulerInstance.tasks[taskIdentifier] = newTask
-	nextRunTime := newTask.Schedule().NextRun(time.Now())
-	slog.Info("Task registered", "task_id", taskIdentifier, "schedule", newTask.Schedule().Description(), "next_run", nextRunTime)
+	nextRunTime := newTask.Schedule().NextRun(schedulerInstance.scheduleTime(schedulerInstance.clock.Now()))
+	schedulerInstance.getLogger().Info("Task registered", "task_id", taskIdentifier, "schedule", newTask.Schedule().Description(), "next_run", nextRunTime)
 
 	if schedulerInstance.isRunning {
-		schedulerInstance.waitGroup.Add(1)
-		go schedulerInstance.executeTask(newTask)
+		schedulerInstance.enqueueNextRunLocked(newTask, schedulerInstance.clock.Now())
 	}
 
 	return nil
@@ -77,162 +147,281 @@ func (schedulerInstance *Scheduler) Start() {
 
 	schedulerInstance.isRunning = true
 	schedulerInstance.stopChannel = make(chan struct{})
+	schedulerInstance.runsContext, schedulerInstance.cancelRuns = context.WithCancel(context.Background())
+	// A fresh wait group, so that Stop does not wait for runs a timed-out StopWithContext left behind.
+	schedulerInstance.waitGroup = &sync.WaitGroup{}
+	schedulerInstance.runQueue = nil
 
+	currentTime := schedulerInstance.clock.Now()
 	for _, taskInstance := range schedulerInstance.tasks {
-		schedulerInstance.waitGroup.Add(1)
-		go schedulerInstance.executeTask(taskInstance)
+		schedulerInstance.enqueueNextRunLocked(taskInstance, currentTime)
+	}
+
+	dueRuns := make(chan *queuedRun)
+	schedulerInstance.waitGroup.Add(1 + schedulerInstance.workerCount)
+	go schedulerInstance.dispatch(dueRuns, schedulerInstance.stopChannel, schedulerInstance.waitGroup)
+	for workerIndex := 0; workerIndex < schedulerInstance.workerCount; workerIndex++ {
+		go schedulerInstance.work(dueRuns, schedulerInstance.runsContext, schedulerInstance.stopChannel, schedulerInstance.waitGroup)
 	}
 
-	slog.Info("Scheduler started", "task_count", len(schedulerInstance.tasks))
+	schedulerInstance.getLogger().Info("Scheduler started", "task_count", len(schedulerInstance.tasks))
 }
 
-// Stop signals the scheduler to stop and waits for running tasks to complete.
+// Stop stops dispatching runs, cancels the contexts of running tasks and waits for them to
+// return. Queued runs are discarded; Start queues every task again. Use StopWithContext to give
+// running tasks time to finish first.
 func (schedulerInstance *Scheduler) Stop() {
-	schedulerInstance.mutex.Lock()
-	if !schedulerInstance.isRunning {
-		schedulerInstance.mutex.Unlock()
+	cancelRuns, waitGroup, wasRunning := schedulerInstance.stopDispatching()
+	if !wasRunning {
 		return
 	}
-	schedulerInstance.isRunning = false
-	close(schedulerInstance.stopChannel)
-	schedulerInstance.mutex.Unlock()
-
-	schedulerInstance.waitGroup.Wait()
-	slog.Info("Scheduler stopped")
+	cancelRuns()
+	waitGroup.Wait()
+	schedulerInstance.getLogger().Info("Scheduler stopped")
 }
 
-// executeTask runs the task according to its schedule
-func (schedulerInstance *Scheduler) executeTask(taskInstance Task) {
-	defer schedulerInstance.waitGroup.Done()
+// runOutcome tells how execute ended a run.
+type runOutcome int
+
+const (
+	// runFinished means BeforeExecute and Run were called until success or the last failure.
+	runFinished runOutcome = iota
+	// runSkipped means the overlap policy skipped the run.
+	runSkipped
+	// runReplaced means a newer run canceled the run under OverlapReplace.
+	runReplaced
+	// runStopped means the scheduler stopped before or during the run.
+	runStopped
+	// runDeferred means the run waits for the task's runs in progress and will be queued again.
+	runDeferred
+)
 
-	for {
-		currentTime := time.Now()
-		nextRunTimePtr := taskInstance.Schedule().NextRun(currentTime)
+// executeRun executes one scheduled run of the task, records it and returns how it ended. A run
+// that is interrupted by the scheduler stopping or deferred by the overlap policy is left unrecorded. Runs skipped or replaced under the overlap
+// policy are recorded like failed ones, so that a one-shot run is not repeated and does not in
+// turn replace the run that replaced it.
+func (schedulerInstance *Scheduler) executeRun(taskInstance Task, scheduledAt time.Time, runsContext context.Context, stopChannel <-chan struct{}) runOutcome {
+	executionError, outcome := schedulerInstance.execute(runsContext, taskInstance, scheduledAt, false, stopChannel)
+	if outcome == runStopped || outcome == runDeferred {
+		return outcome
+	}
+	schedulerInstance.completeRun(taskInstance, scheduledAt, executionError)
+	return outcome
+}
 
-		// If nil returned, the task should never run again
-		if nextRunTimePtr == nil {
-			slog.Info("Task will not run again", "task_id", taskInstance.ID())
-			return
+// execute applies the task's overlap policy and then calls BeforeExecute and Run with contexts
+// derived from runsContext, retrying failed runs, and returns the final error and how the run
+// ended. A run ends early if stopChannel closes during a retry delay, or its context is canceled
+// by shutdown or a replacing run; the last error is returned then. A run skipped or deferred by
+// the overlap policy does not start; skipped runs notify the SkippedRun hooks. Otherwise hooks
+// are notified either way, the error handler only for runs that finished.
+func (schedulerInstance *Scheduler) execute(runsContext context.Context, taskInstance Task, scheduledAt time.Time, onDemand bool, stopChannel <-chan struct{}) (executionError error, outcome runOutcome) {
+	logger := schedulerInstance.getLogger().With("task_id", taskInstance.ID())
+	if onDemand {
+		logger = logger.With("on_demand", true)
+	}
+	runEvent := RunEvent{TaskID: taskInstance.ID(), ScheduledAt: scheduledAt, OnDemand: onDemand, StartedAt: schedulerInstance.clock.Now()}
+	admittedContext, finishRun, admitError := schedulerInstance.admitRun(runsContext, taskInstance, &runEvent, stopChannel)
+	if errors.Is(admitError, ErrRunSkipped) {
+		logger.Warn("Task run skipped, another run is in progress", "scheduled_at", scheduledAt, "error", admitError)
+		runEvent.FinishedAt = runEvent.StartedAt
+		runEvent.Err = admitError
+		for _, hooks := range schedulerInstance.hooks {
+			if hooks.SkippedRun != nil {
+				hooks.SkippedRun(runEvent)
+			}
 		}
+		return admitError, runSkipped
+	}
+	if errors.Is(admitError, errRunDeferred) {
+		logger.Info("Task run deferred until the runs in progress finish", "scheduled_at", scheduledAt)
+		return nil, runDeferred
+	}
+	if admitError != nil {
+		logger.Info("Task run waiting for another run cancelled due to scheduler stopping")
+		return admitError, runStopped
+	}
+	defer finishRun()
 
-		nextRunTime := *nextRunTimePtr
-		waitDuration := nextRunTime.Sub(currentTime)
-
-		// Use a timer to wait until next execution
-		timer := time.NewTimer(waitDuration)
-
-		select {
-		case <-timer.C:
-			// Time to execute the task
-			ctx := context.Background()
-			slog.Info("Executing task", "task_id", taskInstance.ID())
-			contextBefore, cancelBefore := context.WithTimeout(ctx, 30*time.Minute)
-			executionBeforeError := taskInstance.BeforeExecute(contextBefore)
-			cancelBefore()
-
-			if executionBeforeError != nil {
-				slog.Error("Task BeforeExecute failed", "task_id", taskInstance.ID(), "error", executionBeforeError)
-			} else {
-				var retryAttempt int
-				maximumRetries := taskInstance.MaxRetries()
-
-				for retryAttempt = 0; retryAttempt <= maximumRetries; retryAttempt++ {
-					if retryAttempt > 0 {
-						slog.Info("Retrying task after failure", "task_id", taskInstance.ID(), "attempt", retryAttempt, "max_retries", maximumRetries)
-					}
-
-					contextRun, cancelRun := context.WithTimeout(ctx, 30*time.Minute)
-					executionRunError := taskInstance.Run(contextRun)
-					cancelRun()
-
-					if executionRunError == nil {
-						break
-					}
-
-					if retryAttempt < maximumRetries {
-						retryDelayDuration := taskInstance.RetryDelay(retryAttempt)
-						slog.Warn("Task failed, retrying", "task_id", taskInstance.ID(), "attempt", retryAttempt+1, "max_retries", maximumRetries, "retry_delay", retryDelayDuration, "error", executionRunError)
-
-						select {
-						case <-time.After(retryDelayDuration):
-						case <-schedulerInstance.stopChannel:
-							slog.Info("Task retry cancelled due to scheduler stopping", "task_id", taskInstance.ID())
-							return
-						}
-					} else {
-						executionBeforeError = executionRunError
-					}
-				}
-
-				if executionBeforeError != nil {
-					slog.Error("Task failed after retries", "task_id", taskInstance.ID(), "attempts", retryAttempt, "error", executionBeforeError)
-				}
+	for _, hooks := range schedulerInstance.hooks {
+		if hooks.BeforeRun != nil {
+			hooks.BeforeRun(runEvent)
+		}
+	}
+	defer func() {
+		runEvent.FinishedAt = schedulerInstance.clock.Now()
+		runEvent.Err = executionError
+		for _, hooks := range schedulerInstance.hooks {
+			if hooks.AfterRun != nil {
+				hooks.AfterRun(runEvent)
 			}
+		}
+		if executionError != nil && outcome == runFinished && schedulerInstance.errorHandler != nil {
+			schedulerInstance.errorHandler(runEvent)
+		}
+	}()
+
+	logger.Info("Executing task")
+	deadline := deadlineFor(taskInstance, scheduledAt)
+	runContext, cancelRunContext := schedulerInstance.withRunDeadline(admittedContext, deadline)
+	defer cancelRunContext()
+
+	executionError = schedulerInstance.callWithTimeout(taskInstance, "BeforeExecute", runContext, deadline, schedulerInstance.beforeExecuteTimeoutFor(taskInstance), taskInstance.BeforeExecute)
+	if executionError != nil {
+		if admittedContext.Err() != nil {
+			logger.Warn("Task BeforeExecute interrupted", "reason", interruptionReason(admittedContext), "error", executionError)
+			return executionError, interruptedOutcome(admittedContext)
+		}
+		runEvent.TimedOut = errors.Is(executionError, ErrTaskTimeout)
+		if runEvent.TimedOut {
+			logger.Error("Task BeforeExecute timed out", "error", executionError)
+		} else {
+			logger.Error("Task BeforeExecute failed", "error", executionError)
+		}
+		return executionError, runFinished
+	}
 
-			// After executing a task with a OneTimeSchedule
-			if oneTimeSchedule, isOneTime := taskInstance.Schedule().(*OneTimeSchedule); isOneTime {
-				oneTimeSchedule.SignalExecution()
+	runTimeout := schedulerInstance.runTimeoutFor(taskInstance)
+	maximumRetries := taskInstance.MaxRetries()
+	for retryAttempt := 0; retryAttempt <= maximumRetries; retryAttempt++ {
+		if retryAttempt > 0 {
+			logger.Info("Retrying task after failure", "attempt", retryAttempt, "max_retries", maximumRetries)
+		}
+
+		executionError = schedulerInstance.callWithTimeout(taskInstance, "Run", runContext, deadline, runTimeout, taskInstance.Run)
+		runEvent.Attempts = retryAttempt + 1
+		runEvent.TimedOut = errors.Is(executionError, ErrTaskTimeout)
+
+		if executionError == nil {
+			if retryAttempt > 0 {
+				logger.Info("Task succeeded after retry", "attempts", runEvent.Attempts)
 			}
+			return nil, runFinished
+		}
+		if admittedContext.Err() != nil {
+			logger.Warn("Task interrupted", "reason", interruptionReason(admittedContext), "attempts", runEvent.Attempts, "error", executionError)
+			return executionError, interruptedOutcome(admittedContext)
+		}
 
-		case <-schedulerInstance.stopChannel:
-			// Scheduler is stopping
-			timer.Stop()
-			slog.Info("Task stopped", "task_id", taskInstance.ID())
-			return
+		if retryAttempt < maximumRetries {
+			if runContext.Err() != nil {
+				logger.Warn("Task deadline passed, not retrying", "attempt", retryAttempt+1, "deadline", deadline)
+				break
+			}
+			retryDelayDuration := taskInstance.RetryDelay(retryAttempt)
+			logger.Warn("Task failed, retrying", "attempt", retryAttempt+1, "max_retries", maximumRetries, "retry_delay", retryDelayDuration, "timed_out", runEvent.TimedOut, "error", executionError)
+
+			select {
+			case <-schedulerInstance.clock.After(retryDelayDuration):
+			case <-stopChannel:
+				logger.Info("Task retry cancelled due to scheduler stopping")
+				return executionError, runStopped
+			case <-admittedContext.Done():
+				logger.Info("Task retry cancelled", "reason", interruptionReason(admittedContext))
+				return executionError, interruptedOutcome(admittedContext)
+			}
 		}
 	}
+
+	if runEvent.TimedOut {
+		logger.Error("Task timed out", "attempts", runEvent.Attempts, "error", executionError)
+	} else {
+		logger.Error("Task failed after retries", "attempts", runEvent.Attempts, "error", executionError)
+	}
+	return executionError, runFinished
 }
 
-// RunTaskNow executes a task immediately.
-func (schedulerInstance *Scheduler) RunTaskNow(taskIdentifier string) error {
-	schedulerInstance.mutex.Lock()
-	taskInstance, exists := schedulerInstance.tasks[taskIdentifier]
-	schedulerInstance.mutex.Unlock()
+// nextRunTime returns when the task should run next after currentTime, or nil if never.
+// An overdue one-shot run that has not completed yet is due immediately. The caller must
+// hold the scheduler mutex.
+func (schedulerInstance *Scheduler) nextRunTime(taskInstance Task, currentTime time.Time) *time.Time {
+	currentTime = schedulerInstance.scheduleTime(currentTime)
+	oneShot, isOneShot := taskInstance.Schedule().(OneShotSchedule)
+	if !isOneShot {
+		cursor, exists := schedulerInstance.runCursors[taskInstance.ID()]
+		if !exists {
+			cursor = &runCursor{}
+			schedulerInstance.runCursors[taskInstance.ID()] = cursor
+		}
+		return nextRunWithCursor(taskInstance.Schedule(), currentTime, cursor)
+	}
 
-	if !exists {
-		return fmt.Errorf("task not found")
+	runTime := oneShot.RunTime()
+	completed, err := schedulerInstance.completionStore.IsCompleted(taskInstance.ID(), runTime)
+	if err != nil {
+		schedulerInstance.getLogger().Error("Failed to read task completion", "task_id", taskInstance.ID(), "scheduled_at", runTime, "error", err)
 	}
+	if completed {
+		return nil
+	}
+	if runTime.Before(currentTime) {
+		schedulerInstance.getLogger().Info("Running overdue one-shot task", "task_id", taskInstance.ID(), "scheduled_at", runTime)
+		return &currentTime
+	}
+	return &runTime
+}
 
-	slog.Info("Executing task on demand", "task_id", taskIdentifier)
+// scheduleTime returns currentTime in the scheduler's location, if one is set, so that schedules
+// without a Location of their own evaluate their wall-clock times there.
+func (schedulerInstance *Scheduler) scheduleTime(currentTime time.Time) time.Time {
+	if schedulerInstance.location == nil {
+		return currentTime
+	}
+	return currentTime.In(schedulerInstance.location)
+}
 
-	contextBefore, cancelBefore := context.WithTimeout(context.Background(), 30*time.Minute)
-	executionBeforeError := taskInstance.BeforeExecute(contextBefore)
-	cancelBefore()
-	if executionBeforeError != nil {
-		slog.Error("Task BeforeExecute failed", "task_id", taskIdentifier, "error", executionBeforeError)
-		return executionBeforeError
+// completeRun records a finished scheduled run and notifies the schedule if it observes executions.
+func (schedulerInstance *Scheduler) completeRun(taskInstance Task, scheduledAt time.Time, executionError error) {
+	schedule := taskInstance.Schedule()
+	if oneShot, isOneShot := schedule.(OneShotSchedule); isOneShot {
+		// An overdue run starts late but still belongs to its original run time.
+		scheduledAt = oneShot.RunTime()
+		if err := schedulerInstance.getCompletionStore().MarkCompleted(taskInstance.ID(), scheduledAt); err != nil {
+			schedulerInstance.getLogger().Error("Failed to record task completion", "task_id", taskInstance.ID(), "scheduled_at", scheduledAt, "error", err)
+		}
 	}
+	if observer, isObserver := schedule.(ExecutionObserver); isObserver {
+		observer.AfterExecution(scheduledAt, executionError)
+	}
+}
 
-	var retryAttempt int
-	maximumRetries := taskInstance.MaxRetries()
+// getLogger returns the logger set with WithLogger, or the default slog logger.
+func (schedulerInstance *Scheduler) getLogger() *slog.Logger {
+	if schedulerInstance.logger != nil {
+		return schedulerInstance.logger
+	}
+	return slog.Default()
+}
 
-	for retryAttempt = 0; retryAttempt <= maximumRetries; retryAttempt++ {
-		if retryAttempt > 0 {
-			slog.Info("Retrying on-demand task after failure", "task_id", taskIdentifier, "attempt", retryAttempt, "max_retries", maximumRetries)
-		}
+// getCompletionStore returns the current completion store.
+func (schedulerInstance *Scheduler) getCompletionStore() CompletionStore {
+	schedulerInstance.mutex.Lock()
+	defer schedulerInstance.mutex.Unlock()
 
-		contextRun, cancelRun := context.WithTimeout(context.Background(), 30*time.Minute)
-		executionRunError := taskInstance.Run(contextRun)
-		cancelRun()
+	return schedulerInstance.completionStore
+}
 
-		if executionRunError == nil {
-			if retryAttempt > 0 {
-				slog.Info("On-demand task succeeded after retry", "task_id", taskIdentifier, "attempts", retryAttempt+1)
-			} else {
-				slog.Info("On-demand task executed successfully", "task_id", taskIdentifier)
-			}
-			return nil
-		}
+// RunTaskNow executes a task immediately on the calling goroutine and returns its final error.
+// The task's overlap policy applies as to scheduled runs: if it skips the run, the returned error
+// wraps ErrRunSkipped. A run started while the scheduler is running is waited for and canceled
+// by Stop and StopWithContext like scheduled runs.
+func (schedulerInstance *Scheduler) RunTaskNow(taskIdentifier string) error {
+	schedulerInstance.mutex.Lock()
+	taskInstance, exists := schedulerInstance.tasks[taskIdentifier]
+	runsContext := context.Background()
+	if exists && schedulerInstance.isRunning {
+		runsContext = schedulerInstance.runsContext
+		// Added under the mutex while running, so always before Stop starts waiting.
+		waitGroup := schedulerInstance.waitGroup
+		waitGroup.Add(1)
+		defer waitGroup.Done()
+	}
+	schedulerInstance.mutex.Unlock()
 
-		if retryAttempt < maximumRetries {
-			retryDelayDuration := taskInstance.RetryDelay(retryAttempt)
-			slog.Warn("On-demand task failed, retrying", "task_id", taskIdentifier, "attempt", retryAttempt+1, "max_retries", maximumRetries, "retry_delay", retryDelayDuration, "error", executionRunError)
-			time.Sleep(retryDelayDuration)
-		} else {
-			executionBeforeError = executionRunError
-		}
+	if !exists {
+		return fmt.Errorf("task not found")
 	}
 
-	slog.Error("On-demand task execution failed after retries", "task_id", taskIdentifier, "attempts", retryAttempt, "error", executionBeforeError)
-	return executionBeforeError
+	executionError, _ := schedulerInstance.execute(runsContext, taskInstance, schedulerInstance.clock.Now(), true, nil)
+	return executionError
 }
@@ -3,31 +3,33 @@ package scheduler
 import (
 	"context"
 	"fmt"
-	"sync/atomic"
+	"sort"
 	"time"
 )
 
 // DailySchedule runs a task at the same time every day.
 type DailySchedule struct {
-	Hour   int
-	Minute int
+	Hour     int
+	Minute   int
+	Location *time.Location // Optional time zone; see WithLocation.
+	DST      DSTPolicy      // Handling of days with a daylight saving transition.
 }
 
 // NextRun returns the next run time after the provided time.
 func (daily DailySchedule) NextRun(after time.Time) *time.Time {
-	nextRunTime := time.Date(
-		after.Year(), after.Month(), after.Day(),
-		daily.Hour, daily.Minute, 0, 0, after.Location(),
-	)
-	if nextRunTime.Before(after) {
-		nextRunTime = nextRunTime.AddDate(0, 0, 1)
-	}
-	return &nextRunTime
+	return nextWallClockRun(after, resolveLocation(daily.Location, after), daily.DST, func(wallClock time.Time) (time.Time, bool) {
+		return nextDailyWallClock(wallClock, daily.Hour, daily.Minute), true
+	})
 }
 
 // Description returns a description of the daily schedule.
 func (daily DailySchedule) Description() string {
-	return fmt.Sprintf("Daily at %02d:%02d", daily.Hour, daily.Minute)
+	return fmt.Sprintf("Daily at %02d:%02d", daily.Hour, daily.Minute) + describeLocation(daily.Location)
+}
+
+// Validate checks that the schedule has a valid time of day.
+func (daily DailySchedule) Validate() error {
+	return validateTimeOfDay("DailySchedule", daily.Hour, daily.Minute)
 }
 
 // WeekdaySchedule runs a task on specified weekdays at a given time.
@@ -35,36 +37,240 @@ type WeekdaySchedule struct {
 	Weekdays []time.Weekday
 	Hour     int
 	Minute   int
+	Location *time.Location // Optional time zone; see WithLocation.
+	DST      DSTPolicy      // Handling of days with a daylight saving transition.
 }
 
 // NextRun returns the next run time after the provided time.
 func (weekday WeekdaySchedule) NextRun(after time.Time) *time.Time {
-	nextRunTime := time.Date(
-		after.Year(), after.Month(), after.Day(),
-		weekday.Hour, weekday.Minute, 0, 0, after.Location(),
-	)
-	if nextRunTime.Before(after) {
-		nextRunTime = nextRunTime.AddDate(0, 0, 1)
-	}
-	for !containsWeekday(weekday.Weekdays, nextRunTime.Weekday()) {
-		nextRunTime = nextRunTime.AddDate(0, 0, 1)
-	}
-	return &nextRunTime
+	return nextWallClockRun(after, resolveLocation(weekday.Location, after), weekday.DST, func(wallClock time.Time) (time.Time, bool) {
+		candidate := nextDailyWallClock(wallClock, weekday.Hour, weekday.Minute)
+		for dayOffset := 0; dayOffset < 7; dayOffset++ {
+			if containsWeekday(weekday.Weekdays, candidate.Weekday()) {
+				return candidate, true
+			}
+			candidate = candidate.AddDate(0, 0, 1)
+		}
+		return time.Time{}, false
+	})
 }
 
 // Description returns a description of the weekday schedule.
 func (weekday WeekdaySchedule) Description() string {
-	return fmt.Sprintf("At %02d:%02d on %v", weekday.Hour, weekday.Minute, weekday.Weekdays)
+	return fmt.Sprintf("At %02d:%02d on %v", weekday.Hour, weekday.Minute, weekday.Weekdays) + describeLocation(weekday.Location)
+}
+
+// Validate checks that the schedule has at least one weekday and a valid time of day.
+// Without weekdays NextRun never finds a match and returns nil.
+func (weekday WeekdaySchedule) Validate() error {
+	if err := validateWeekdays("WeekdaySchedule", "Weekdays", weekday.Weekdays); err != nil {
+		return err
+	}
+	return validateTimeOfDay("WeekdaySchedule", weekday.Hour, weekday.Minute)
+}
+
+// nextDailyWallClock returns the first wall-clock time at hour:minute at or after wallClock.
+func nextDailyWallClock(wallClock time.Time, hour int, minute int) time.Time {
+	candidate := time.Date(wallClock.Year(), wallClock.Month(), wallClock.Day(), hour, minute, 0, 0, time.UTC)
+	if candidate.Before(wallClock) {
+		candidate = candidate.AddDate(0, 0, 1)
+	}
+	return candidate
+}
+
+// monthlySearchMonths bounds how many months MonthlySchedule.NextRun looks ahead.
+const monthlySearchMonths = 48
+
+// ShortMonthPolicy decides what MonthlySchedule does when a configured day does not exist in a month.
+type ShortMonthPolicy int
+
+const (
+	// ShortMonthSkip skips months that are too short, so day 31 only runs in 31-day months.
+	ShortMonthSkip ShortMonthPolicy = iota
+	// ShortMonthClamp runs on the nearest existing day instead, so day 31 runs on the 30th in April.
+	ShortMonthClamp
+)
+
+// NthWeekday selects the Nth occurrence of a weekday within a month.
+// N counts from the start of the month (1 is the first) or, when negative, from its end (-1 is the last).
+type NthWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// MonthlySchedule runs a task on selected days of every month at a given time.
+// A day is selected if it matches any entry of Days or NthWeekdays.
+type MonthlySchedule struct {
+	Days        []int        // Days of the month; negative values count back from the end, -1 being the last day.
+	NthWeekdays []NthWeekday // Weekday occurrences such as the 2nd Tuesday or the last Friday.
+	ShortMonths ShortMonthPolicy
+	Hour        int
+	Minute      int
+	Location    *time.Location // Optional time zone; see WithLocation.
+	DST         DSTPolicy      // Handling of days with a daylight saving transition.
+}
+
+// NextRun returns the next run time after the provided time.
+func (monthly MonthlySchedule) NextRun(after time.Time) *time.Time {
+	return nextWallClockRun(after, resolveLocation(monthly.Location, after), monthly.DST, func(wallClock time.Time) (time.Time, bool) {
+		for monthOffset := 0; monthOffset < monthlySearchMonths; monthOffset++ {
+			monthStart := time.Date(wallClock.Year(), wallClock.Month()+time.Month(monthOffset), 1, 0, 0, 0, 0, time.UTC)
+			for _, day := range monthly.daysIn(monthStart.Year(), monthStart.Month()) {
+				candidate := time.Date(monthStart.Year(), monthStart.Month(), day, monthly.Hour, monthly.Minute, 0, 0, time.UTC)
+				if !candidate.Before(wallClock) {
+					return candidate, true
+				}
+			}
+		}
+		return time.Time{}, false
+	})
+}
+
+// daysIn returns the sorted days of the given month on which the schedule runs.
+func (monthly MonthlySchedule) daysIn(year int, month time.Month) []int {
+	monthLength := daysInMonth(year, month)
+	selectedDays := make(map[int]bool)
+	for _, day := range monthly.Days {
+		if day < 0 {
+			day = monthLength + 1 + day
+		}
+		if day < 1 || day > monthLength {
+			if monthly.ShortMonths == ShortMonthSkip || day == 0 {
+				continue
+			}
+			day = max(1, min(day, monthLength))
+		}
+		selectedDays[day] = true
+	}
+	for _, nthWeekday := range monthly.NthWeekdays {
+		if day, exists := nthWeekdayOfMonth(year, month, nthWeekday); exists {
+			selectedDays[day] = true
+		}
+	}
+
+	days := make([]int, 0, len(selectedDays))
+	for day := range selectedDays {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Description returns a description of the monthly schedule.
+func (monthly MonthlySchedule) Description() string {
+	var dayPhrases []string
+	for _, day := range monthly.Days {
+		switch {
+		case day == -1:
+			dayPhrases = append(dayPhrases, "the last day")
+		case day < 0:
+			dayPhrases = append(dayPhrases, fmt.Sprintf("the %s to last day", ordinal(-day)))
+		default:
+			dayPhrases = append(dayPhrases, "the "+ordinal(day))
+		}
+	}
+	for _, nthWeekday := range monthly.NthWeekdays {
+		switch {
+		case nthWeekday.N == -1:
+			dayPhrases = append(dayPhrases, fmt.Sprintf("the last %s", nthWeekday.Weekday))
+		case nthWeekday.N < 0:
+			dayPhrases = append(dayPhrases, fmt.Sprintf("the %s to last %s", ordinal(-nthWeekday.N), nthWeekday.Weekday))
+		default:
+			dayPhrases = append(dayPhrases, fmt.Sprintf("the %s %s", ordinal(nthWeekday.N), nthWeekday.Weekday))
+		}
+	}
+	shortMonthNote := ""
+	if monthly.ShortMonths == ShortMonthClamp {
+		for _, day := range monthly.Days {
+			if day > 28 || day < -28 {
+				shortMonthNote = " (clamped in shorter months)"
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("Monthly on %s%s at %02d:%02d", joinWithAnd(dayPhrases), shortMonthNote, monthly.Hour, monthly.Minute) +
+		describeLocation(monthly.Location)
+}
+
+// Validate checks the selected days and the time of day.
+func (monthly MonthlySchedule) Validate() error {
+	if len(monthly.Days) == 0 && len(monthly.NthWeekdays) == 0 {
+		return &ScheduleFieldError{Schedule: "MonthlySchedule", Field: "Days", Reason: "or NthWeekdays must be set"}
+	}
+	for index, day := range monthly.Days {
+		if day == 0 || day < -31 || day > 31 {
+			return &ScheduleFieldError{Schedule: "MonthlySchedule", Field: fmt.Sprintf("Days[%d]", index), Reason: fmt.Sprintf("must be between 1 and 31 or -31 and -1, got %d", day)}
+		}
+	}
+	for index, nthWeekday := range monthly.NthWeekdays {
+		field := fmt.Sprintf("NthWeekdays[%d]", index)
+		if nthWeekday.N == 0 || nthWeekday.N < -5 || nthWeekday.N > 5 {
+			return &ScheduleFieldError{Schedule: "MonthlySchedule", Field: field + ".N", Reason: fmt.Sprintf("must be between 1 and 5 or -5 and -1, got %d", nthWeekday.N)}
+		}
+		if err := validateWeekdays("MonthlySchedule", field+".Weekday", []time.Weekday{nthWeekday.Weekday}); err != nil {
+			return err
+		}
+	}
+	if monthly.ShortMonths != ShortMonthSkip && monthly.ShortMonths != ShortMonthClamp {
+		return &ScheduleFieldError{Schedule: "MonthlySchedule", Field: "ShortMonths", Reason: fmt.Sprintf("holds unknown policy %d", monthly.ShortMonths)}
+	}
+	return validateTimeOfDay("MonthlySchedule", monthly.Hour, monthly.Minute)
+}
+
+// nthWeekdayOfMonth returns the day of the month of the given weekday occurrence, if it exists.
+func nthWeekdayOfMonth(year int, month time.Month, nthWeekday NthWeekday) (int, bool) {
+	monthLength := daysInMonth(year, month)
+	switch {
+	case nthWeekday.N > 0:
+		firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		day := 1 + (int(nthWeekday.Weekday)-int(firstWeekday)+7)%7 + (nthWeekday.N-1)*7
+		return day, day <= monthLength
+	case nthWeekday.N < 0:
+		lastWeekday := time.Date(year, month, monthLength, 0, 0, 0, 0, time.UTC).Weekday()
+		day := monthLength - (int(lastWeekday)-int(nthWeekday.Weekday)+7)%7 + (nthWeekday.N+1)*7
+		return day, day >= 1
+	}
+	return 0, false
+}
+
+// ordinal formats a positive number as "1st", "2nd", "3rd", "4th" and so on.
+func ordinal(number int) string {
+	suffix := "th"
+	switch {
+	case number%100 >= 11 && number%100 <= 13:
+	case number%10 == 1:
+		suffix = "st"
+	case number%10 == 2:
+		suffix = "nd"
+	case number%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", number, suffix)
 }
 
 // IntervalSchedule runs a task repeatedly at a fixed time interval.
+//
+// Without StartTime or Aligned, intervals are counted from the time NextRun is first asked, so they
+// move with every restart. With Aligned, runs fall on wall-clock multiples of Interval in Location:
+// every 15 minutes runs at :00, :15, :30 and :45, and every hour at the top of the hour. Intervals
+// shorter than a day restart from midnight each day, so 7h runs at 00:00, 07:00, 14:00 and 21:00;
+// longer intervals are counted from midnight on January 1, 1970.
 type IntervalSchedule struct {
 	Interval  time.Duration
-	StartTime time.Time // Optional start time.
+	StartTime time.Time      // Optional start time; with Aligned, runs before it are dropped.
+	Aligned   bool           // Align runs to wall-clock multiples of Interval.
+	Location  *time.Location // Time zone for Aligned; see WithLocation.
+	DST       DSTPolicy      // Handling of days with a daylight saving transition when Aligned.
 }
 
-// NextRun returns the next run time after the provided time.
+// NextRun returns the next run time after the provided time, or nil if Interval is not positive.
 func (interval IntervalSchedule) NextRun(after time.Time) *time.Time {
+	if interval.Interval <= 0 {
+		return nil
+	}
+	if interval.Aligned {
+		return interval.nextAlignedRun(after)
+	}
 	startRunTime := interval.StartTime
 	if startRunTime.IsZero() {
 		startRunTime = after
@@ -78,11 +284,49 @@ func (interval IntervalSchedule) NextRun(after time.Time) *time.Time {
 	return &nextRunTime
 }
 
+// nextAlignedRun returns the first wall-clock aligned run after the provided time and not before StartTime.
+func (interval IntervalSchedule) nextAlignedRun(after time.Time) *time.Time {
+	searchFrom := after
+	if !interval.StartTime.IsZero() && interval.StartTime.After(after) {
+		searchFrom = interval.StartTime.Add(-time.Nanosecond)
+	}
+	return nextWallClockRun(searchFrom, resolveLocation(interval.Location, searchFrom), interval.DST, func(wallClock time.Time) (time.Time, bool) {
+		anchor := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if interval.Interval < 24*time.Hour {
+			anchor = time.Date(wallClock.Year(), wallClock.Month(), wallClock.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		elapsedTime := wallClock.Sub(anchor)
+		candidate := anchor.Add(elapsedTime / interval.Interval * interval.Interval)
+		if candidate.Before(wallClock) {
+			candidate = candidate.Add(interval.Interval)
+		}
+		// A daily anchor restarts the count at midnight when the interval does not divide the day.
+		if interval.Interval < 24*time.Hour && candidate.Day() != wallClock.Day() {
+			candidate = time.Date(wallClock.Year(), wallClock.Month(), wallClock.Day()+1, 0, 0, 0, 0, time.UTC)
+		}
+		return candidate, true
+	})
+}
+
 // Description returns a description of the interval schedule.
 func (interval IntervalSchedule) Description() string {
+	if interval.Aligned {
+		return fmt.Sprintf("Every %s, aligned to the clock", interval.Interval) + describeLocation(interval.Location)
+	}
 	return fmt.Sprintf("Every %s", interval.Interval)
 }
 
+// Validate checks that the interval is positive and, when aligned, a whole number of seconds.
+func (interval IntervalSchedule) Validate() error {
+	if interval.Interval <= 0 {
+		return &ScheduleFieldError{Schedule: "IntervalSchedule", Field: "Interval", Reason: fmt.Sprintf("must be positive, got %s", interval.Interval)}
+	}
+	if interval.Aligned && interval.Interval%time.Second != 0 {
+		return &ScheduleFieldError{Schedule: "IntervalSchedule", Field: "Interval", Reason: fmt.Sprintf("must be a whole number of seconds when aligned, got %s", interval.Interval)}
+	}
+	return nil
+}
+
 // containsWeekday checks if targetWeekday is in the list of weekdays.
 func containsWeekday(weekdays []time.Weekday, targetWeekday time.Weekday) bool {
 	for _, weekday := range weekdays {
@@ -93,10 +337,11 @@ func containsWeekday(weekdays []time.Weekday, targetWeekday time.Weekday) bool {
 	return false
 }
 
-// OneTimeSchedule implements a schedule that runs once and then never again
+// OneTimeSchedule implements a schedule that runs once and then never again.
+// NextRun has no side effects; the scheduler records the completed run, and runs it
+// immediately if it was missed while the scheduler was not running.
 type OneTimeSchedule struct {
 	runTime          time.Time
-	hasExecuted      atomic.Bool
 	executionChannel chan struct{}
 }
 
@@ -108,33 +353,36 @@ func NewOneTimeSchedule(executeAt time.Time) *OneTimeSchedule {
 	}
 }
 
-// NextRun returns the next execution time or nil if already executed
+// NextRun returns the run time if it is after the provided time, or nil otherwise.
 func (schedule *OneTimeSchedule) NextRun(afterTime time.Time) *time.Time {
-	// First check if already executed
-	if schedule.hasExecuted.Load() {
-		return nil // Return nil to indicate no more runs
+	if !schedule.runTime.After(afterTime) {
+		return nil
 	}
-
-	// If we're past the scheduled time but haven't executed yet
-	if afterTime.After(schedule.runTime) {
-		// Mark as executed atomically
-		schedule.hasExecuted.CompareAndSwap(false, true)
-
-		// Return current time to run now
-		now := afterTime.Add(time.Millisecond)
-		return &now
-	}
-
-	// Return the scheduled time
 	result := schedule.runTime
 	return &result
 }
 
+// RunTime returns the time the schedule was created to run at.
+func (schedule *OneTimeSchedule) RunTime() time.Time {
+	return schedule.runTime
+}
+
 // Description returns a human-readable description of the schedule
 func (schedule *OneTimeSchedule) Description() string {
 	return "One-time execution at " + schedule.runTime.Format("15:04:05")
 }
 
+// Validate checks that the schedule was created with NewOneTimeSchedule and a run time.
+func (schedule *OneTimeSchedule) Validate() error {
+	if schedule == nil || schedule.executionChannel == nil {
+		return &ScheduleFieldError{Schedule: "OneTimeSchedule", Field: "runTime", Reason: "is not set; use NewOneTimeSchedule"}
+	}
+	if schedule.runTime.IsZero() {
+		return &ScheduleFieldError{Schedule: "OneTimeSchedule", Field: "runTime", Reason: "must not be zero"}
+	}
+	return nil
+}
+
 // WaitForExecution waits for the execution to complete
 func (schedule *OneTimeSchedule) WaitForExecution(ctx context.Context) bool {
 	select {
@@ -145,6 +393,11 @@ func (schedule *OneTimeSchedule) WaitForExecution(ctx context.Context) bool {
 	}
 }
 
+// AfterExecution signals WaitForExecution once the scheduler has executed the run.
+func (schedule *OneTimeSchedule) AfterExecution(scheduledAt time.Time, err error) {
+	schedule.SignalExecution()
+}
+
 // SignalExecution signals that the task has completed execution
 func (schedule *OneTimeSchedule) SignalExecution() {
 	// Non-blocking send to prevent hanging if WaitForExecution isn't called
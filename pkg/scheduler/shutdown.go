@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ShutdownError reports the tasks that were still running when the grace period given to
+// StopWithContext ended. It wraps ErrShutdownTimeout and the context's error.
+type ShutdownError struct {
+	RunningTasks []RunEvent // Runs whose contexts were canceled, ordered by start time.
+	Err          error      // Error of the context that ended the grace period.
+}
+
+func (shutdownError *ShutdownError) Error() string {
+	taskIdentifiers := make([]string, len(shutdownError.RunningTasks))
+	for index, runEvent := range shutdownError.RunningTasks {
+		taskIdentifiers[index] = runEvent.TaskID
+	}
+	return fmt.Sprintf("%v: %d tasks still running: %s", ErrShutdownTimeout, len(taskIdentifiers), strings.Join(taskIdentifiers, ", "))
+}
+
+func (shutdownError *ShutdownError) Unwrap() []error {
+	return []error{ErrShutdownTimeout, shutdownError.Err}
+}
+
+// StopWithContext stops dispatching runs and waits until ctx is done for running tasks to
+// finish, including those started by RunTaskNow while the scheduler was running. It then cancels
+// the contexts of the tasks still running and returns a *ShutdownError listing them, without
+// waiting for them to return. Runs that ignore cancellation keep going in the background: a
+// later Start does not wait for them, and a later Stop does not wait for them either, but until
+// they return they still count as in progress for RunningTasks and their tasks' overlap policies.
+func (schedulerInstance *Scheduler) StopWithContext(ctx context.Context) error {
+	cancelRuns, waitGroup, wasRunning := schedulerInstance.stopDispatching()
+	if !wasRunning {
+		return nil
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		waitGroup.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		cancelRuns()
+		schedulerInstance.getLogger().Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+	}
+
+	runningTasks := schedulerInstance.RunningTasks()
+	cancelRuns()
+	if len(runningTasks) == 0 {
+		schedulerInstance.getLogger().Info("Scheduler stopped")
+		return nil
+	}
+	shutdownError := &ShutdownError{RunningTasks: runningTasks, Err: ctx.Err()}
+	for _, runEvent := range runningTasks {
+		schedulerInstance.getLogger().Warn("Canceled task still running at shutdown", "task_id", runEvent.TaskID, "started_at", runEvent.StartedAt, "on_demand", runEvent.OnDemand)
+	}
+	schedulerInstance.getLogger().Warn("Scheduler stopped with tasks still running", "error", shutdownError)
+	return shutdownError
+}
+
+// Shutdown is StopWithContext, named after http.Server.Shutdown. As with StopWithContext, runs
+// still going when ctx is done are canceled but not waited for, and a later Start or Stop does
+// not wait for them either.
+func (schedulerInstance *Scheduler) Shutdown(ctx context.Context) error {
+	return schedulerInstance.StopWithContext(ctx)
+}
+
+// RunningTasks returns the runs in progress, scheduled and on demand, ordered by start time.
+// Their Attempts, FinishedAt and Err fields are not filled in.
+func (schedulerInstance *Scheduler) RunningTasks() []RunEvent {
+	schedulerInstance.mutex.Lock()
+	defer schedulerInstance.mutex.Unlock()
+
+	runningTasks := make([]RunEvent, 0, len(schedulerInstance.activeRuns))
+	for _, runEvent := range schedulerInstance.activeRuns {
+		runningTasks = append(runningTasks, runEvent)
+	}
+	sort.Slice(runningTasks, func(first, second int) bool {
+		if runningTasks[first].StartedAt.Equal(runningTasks[second].StartedAt) {
+			return runningTasks[first].TaskID < runningTasks[second].TaskID
+		}
+		return runningTasks[first].StartedAt.Before(runningTasks[second].StartedAt)
+	})
+	return runningTasks
+}
+
+// stopDispatching marks the scheduler stopped and stops the dispatcher, the workers once their
+// current run is over, and retry delays. It returns the function that cancels the contexts of
+// running tasks and the wait group of the Start-Stop cycle, or reports false if the scheduler
+// was not running.
+func (schedulerInstance *Scheduler) stopDispatching() (context.CancelFunc, *sync.WaitGroup, bool) {
+	schedulerInstance.mutex.Lock()
+	defer schedulerInstance.mutex.Unlock()
+
+	if !schedulerInstance.isRunning {
+		return nil, nil, false
+	}
+	schedulerInstance.isRunning = false
+	close(schedulerInstance.stopChannel)
+	return schedulerInstance.cancelRuns, schedulerInstance.waitGroup, true
+}
@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// solarSearchDays bounds how many days SolarSchedule.NextRun looks ahead, enough to get past a polar night.
+const solarSearchDays = 370
+
+// SolarEvent is a point in the daily course of the sun.
+type SolarEvent int
+
+const (
+	// Sunrise is when the upper edge of the sun appears on the horizon.
+	Sunrise SolarEvent = iota
+	// Sunset is when the upper edge of the sun disappears below the horizon.
+	Sunset
+	// CivilDawn is when the sun's center rises to 6° below the horizon, the start of morning twilight.
+	CivilDawn
+	// CivilDusk is when the sun's center sinks to 6° below the horizon, the end of evening twilight.
+	CivilDusk
+)
+
+// solarEventNames maps solar events to their descriptions.
+var solarEventNames = map[SolarEvent]string{
+	Sunrise:   "sunrise",
+	Sunset:    "sunset",
+	CivilDawn: "civil dawn",
+	CivilDusk: "civil dusk",
+}
+
+// String returns the name of the event, such as "civil dusk".
+func (event SolarEvent) String() string {
+	if name, exists := solarEventNames[event]; exists {
+		return name
+	}
+	return fmt.Sprintf("SolarEvent(%d)", int(event))
+}
+
+// PolarPolicy decides what SolarSchedule does on days when its event does not happen, such as
+// sunset during the polar day or sunrise during the polar night.
+type PolarPolicy int
+
+const (
+	// PolarSkip skips those days.
+	PolarSkip PolarPolicy = iota
+	// PolarNearest runs when the sun comes closest to the event: at solar noon when it never rises
+	// high enough, and at solar midnight when it never sinks low enough.
+	PolarNearest
+)
+
+// SolarSchedule runs a task at a solar event at fixed coordinates, computed locally with the NOAA
+// sunrise equation, which is accurate to about a minute away from the poles. Location decides
+// which calendar day an event belongs to.
+type SolarSchedule struct {
+	Event     SolarEvent
+	Latitude  float64        // Degrees north; negative for south.
+	Longitude float64        // Degrees east; negative for west.
+	Offset    time.Duration  // Shift from the event; negative values run before it.
+	Polar     PolarPolicy    // Handling of days without the event.
+	Location  *time.Location // Optional time zone; see WithLocation.
+}
+
+// Before returns a copy of the schedule that runs offset before the event.
+func (solar SolarSchedule) Before(offset time.Duration) SolarSchedule {
+	solar.Offset = -offset
+	return solar
+}
+
+// After returns a copy of the schedule that runs offset after the event.
+func (solar SolarSchedule) After(offset time.Duration) SolarSchedule {
+	solar.Offset = offset
+	return solar
+}
+
+// NextRun returns the next run time after the provided time.
+func (solar SolarSchedule) NextRun(after time.Time) *time.Time {
+	runTime, _, found := solar.nextSolarRun(after)
+	if !found {
+		return nil
+	}
+	return &runTime
+}
+
+// ExplainNextRun notes when the next run falls on a polar day or night, such as
+// "no sunset during the polar day; running at solar midnight".
+func (solar SolarSchedule) ExplainNextRun(after time.Time) string {
+	_, polarState, found := solar.nextSolarRun(after)
+	if !found {
+		return ""
+	}
+	switch polarState {
+	case solarNeverReached:
+		return fmt.Sprintf("no %s during the polar night; running at solar noon", solar.Event)
+	case solarNeverLeft:
+		return fmt.Sprintf("no %s during the polar day; running at solar midnight", solar.Event)
+	}
+	return ""
+}
+
+// Description returns a description such as "30m0s before sunset at 52.5200°N 13.4050°E".
+func (solar SolarSchedule) Description() string {
+	description := "At " + solar.Event.String()
+	switch {
+	case solar.Offset < 0:
+		description = fmt.Sprintf("%s before %s", -solar.Offset, solar.Event)
+	case solar.Offset > 0:
+		description = fmt.Sprintf("%s after %s", solar.Offset, solar.Event)
+	}
+	description += " at " + formatCoordinates(solar.Latitude, solar.Longitude)
+	if solar.Polar == PolarNearest {
+		description += ", nearest time on polar days and nights"
+	}
+	return description + describeLocation(solar.Location)
+}
+
+// Validate checks the event, the coordinates and the polar policy.
+func (solar SolarSchedule) Validate() error {
+	if _, exists := solarEventNames[solar.Event]; !exists {
+		return &ScheduleFieldError{Schedule: "SolarSchedule", Field: "Event", Reason: fmt.Sprintf("holds unknown event %d", solar.Event)}
+	}
+	if math.IsNaN(solar.Latitude) || solar.Latitude < -90 || solar.Latitude > 90 {
+		return &ScheduleFieldError{Schedule: "SolarSchedule", Field: "Latitude", Reason: fmt.Sprintf("must be between -90 and 90, got %g", solar.Latitude)}
+	}
+	if math.IsNaN(solar.Longitude) || solar.Longitude < -180 || solar.Longitude > 180 {
+		return &ScheduleFieldError{Schedule: "SolarSchedule", Field: "Longitude", Reason: fmt.Sprintf("must be between -180 and 180, got %g", solar.Longitude)}
+	}
+	if solar.Polar != PolarSkip && solar.Polar != PolarNearest {
+		return &ScheduleFieldError{Schedule: "SolarSchedule", Field: "Polar", Reason: fmt.Sprintf("holds unknown policy %d", solar.Polar)}
+	}
+	return nil
+}
+
+// solarPolarState tells whether the sun crosses the altitude of an event on a given day.
+type solarPolarState int
+
+const (
+	solarCrossed      solarPolarState = iota
+	solarNeverReached                 // The sun stays below the event's altitude all day.
+	solarNeverLeft                    // The sun stays above the event's altitude all day.
+)
+
+// nextSolarRun returns the first run after the provided time and the polar state of its day.
+func (solar SolarSchedule) nextSolarRun(after time.Time) (time.Time, solarPolarState, bool) {
+	location := resolveLocation(solar.Location, after)
+	// Start a day early: an offset can move an event into the previous or next day.
+	localDate := civilDate(after.In(location)).AddDate(0, 0, -1)
+	for dayOffset := 0; dayOffset < solarSearchDays; dayOffset++ {
+		eventTime, polarState := solar.eventOn(localDate.AddDate(0, 0, dayOffset))
+		if polarState != solarCrossed && solar.Polar == PolarSkip {
+			continue
+		}
+		runTime := eventTime.Add(solar.Offset).In(location)
+		if runTime.After(after) {
+			return runTime, polarState, true
+		}
+	}
+	return time.Time{}, solarCrossed, false
+}
+
+// eventOn returns the instant of the event on the given civil date, or the nearest instant when
+// the sun does not cross the event's altitude that day.
+func (solar SolarSchedule) eventOn(date time.Time) (time.Time, solarPolarState) {
+	const julianDateOfJ2000 = 2451545.0
+	j2000 := time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+	dayNumber := math.Round(date.Sub(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+
+	meanSolarTime := dayNumber - solar.Longitude/360
+	meanAnomaly := math.Mod(357.5291+0.98560028*meanSolarTime, 360)
+	center := 1.9148*sinDegrees(meanAnomaly) + 0.0200*sinDegrees(2*meanAnomaly) + 0.0003*sinDegrees(3*meanAnomaly)
+	eclipticLongitude := math.Mod(meanAnomaly+center+180+102.9372, 360)
+	solarTransit := julianDateOfJ2000 + meanSolarTime + 0.0053*sinDegrees(meanAnomaly) - 0.0069*sinDegrees(2*eclipticLongitude)
+	declination := math.Asin(sinDegrees(eclipticLongitude) * sinDegrees(23.4397))
+
+	altitude := -0.833
+	if solar.Event == CivilDawn || solar.Event == CivilDusk {
+		altitude = -6
+	}
+	latitude := solar.Latitude * math.Pi / 180
+	hourAngleCosine := (sinDegrees(altitude) - math.Sin(latitude)*math.Sin(declination)) / (math.Cos(latitude) * math.Cos(declination))
+
+	isMorning := solar.Event == Sunrise || solar.Event == CivilDawn
+	julianDate := solarTransit
+	polarState := solarCrossed
+	switch {
+	case hourAngleCosine > 1:
+		polarState = solarNeverReached
+	case hourAngleCosine < -1:
+		polarState = solarNeverLeft
+		if isMorning {
+			julianDate -= 0.5
+		} else {
+			julianDate += 0.5
+		}
+	default:
+		hourAngle := math.Acos(hourAngleCosine) * 180 / math.Pi
+		if isMorning {
+			julianDate -= hourAngle / 360
+		} else {
+			julianDate += hourAngle / 360
+		}
+	}
+	eventTime := j2000.Add(time.Duration((julianDate - julianDateOfJ2000) * 24 * float64(time.Hour)))
+	return eventTime.Truncate(time.Second), polarState
+}
+
+// sinDegrees returns the sine of an angle given in degrees.
+func sinDegrees(degrees float64) float64 {
+	return math.Sin(degrees * math.Pi / 180)
+}
+
+// formatCoordinates formats a position such as "52.5200°N 13.4050°E".
+func formatCoordinates(latitude float64, longitude float64) string {
+	latitudeHemisphere, longitudeHemisphere := "N", "E"
+	if latitude < 0 {
+		latitudeHemisphere, latitude = "S", -latitude
+	}
+	if longitude < 0 {
+		longitudeHemisphere, longitude = "W", -longitude
+	}
+	return fmt.Sprintf("%.4f°%s %.4f°%s", latitude, latitudeHemisphere, longitude, longitudeHemisphere)
+}
@@ -0,0 +1,347 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// onCalendarSearchYears bounds how far ahead OnCalendarSchedule.NextRun searches for a matching time.
+const onCalendarSearchYears = 50
+
+// onCalendarShorthands maps the systemd shorthand expressions to their normalized forms.
+var onCalendarShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// onCalendarComponent describes the allowed values of one component of a calendar event.
+type onCalendarComponent struct {
+	name    string
+	minimum int
+	maximum int
+}
+
+var (
+	onCalendarYear   = onCalendarComponent{name: "year", minimum: 1970, maximum: 2199}
+	onCalendarMonth  = onCalendarComponent{name: "month", minimum: 1, maximum: 12}
+	onCalendarDay    = onCalendarComponent{name: "day", minimum: 1, maximum: 31}
+	onCalendarHour   = onCalendarComponent{name: "hour", minimum: 0, maximum: 23}
+	onCalendarMinute = onCalendarComponent{name: "minute", minimum: 0, maximum: 59}
+	onCalendarSecond = onCalendarComponent{name: "second", minimum: 0, maximum: 59}
+)
+
+// onCalendarField holds the parsed values of one component.
+type onCalendarField struct {
+	allowed  []bool
+	wildcard bool
+}
+
+// matches reports whether value is allowed by the field.
+func (field onCalendarField) matches(value int) bool {
+	return field.wildcard || (value >= 0 && value < len(field.allowed) && field.allowed[value])
+}
+
+// OnCalendarSchedule runs a task according to a systemd calendar event expression, the value of
+// OnCalendar= in a timer unit, so that timers can be ported without rewriting their schedules.
+//
+// The expression has the form "[weekdays] [year-month-day] [hour:minute[:second]] [time zone]".
+// Every part is optional: the date defaults to *-*-*, the time to 00:00:00 and the seconds to 00.
+// Each number may be "*", a list "1,15", a range "1..5" or a repetition "0/15" (from 0 every 15);
+// weekdays are names such as "Mon..Fri" or "Sat,Sun". Separating the day with "~" instead of "-"
+// counts from the end of the month, so "*-*~01" is the last day. Unlike cron, a date and weekdays
+// must both match. The shorthands minutely, hourly, daily, weekly, monthly, quarterly,
+// semiannually, yearly and annually are accepted too.
+type OnCalendarSchedule struct {
+	Location    *time.Location // Optional time zone; see WithLocation.
+	DST         DSTPolicy      // Handling of days with a daylight saving transition.
+	expression  string
+	normalized  string
+	weekdays    onCalendarField
+	years       onCalendarField
+	months      onCalendarField
+	days        onCalendarField
+	daysFromEnd bool
+	hours       onCalendarField
+	minutes     onCalendarField
+	seconds     onCalendarField
+}
+
+// NewOnCalendarSchedule parses a systemd calendar event expression such as "Mon..Fri *-*-* 09:00:00",
+// "*-*-01 00:00" or "hourly", optionally followed by a time zone such as "UTC" or "Europe/Berlin".
+func NewOnCalendarSchedule(expression string) (OnCalendarSchedule, error) {
+	schedule := OnCalendarSchedule{expression: strings.TrimSpace(expression)}
+	parts := strings.Fields(schedule.expression)
+	if len(parts) == 0 {
+		return OnCalendarSchedule{}, fmt.Errorf("%w: empty expression", ErrInvalidOnCalendar)
+	}
+	if shorthand, exists := onCalendarShorthands[strings.ToLower(parts[0])]; exists {
+		parts = append(strings.Fields(shorthand), parts[1:]...)
+	}
+
+	weekdayText, dateText, timeText := "", "*-*-*", "00:00:00"
+	index := 0
+	if index < len(parts) && isOnCalendarWeekdays(parts[index]) {
+		weekdayText = parts[index]
+		index++
+	}
+	if index < len(parts) && isOnCalendarDate(parts[index]) {
+		dateText = parts[index]
+		index++
+	}
+	if index < len(parts) && strings.Contains(parts[index], ":") {
+		timeText = parts[index]
+		index++
+	}
+	if index < len(parts) {
+		if index != len(parts)-1 {
+			return OnCalendarSchedule{}, fmt.Errorf("%w: unexpected %q in %q", ErrInvalidOnCalendar, parts[index], expression)
+		}
+		location, err := time.LoadLocation(parts[index])
+		if err != nil {
+			return OnCalendarSchedule{}, fmt.Errorf("%w: unknown time zone or component %q in %q", ErrInvalidOnCalendar, parts[index], expression)
+		}
+		schedule.Location = location
+	}
+
+	var err error
+	if schedule.weekdays, err = parseOnCalendarWeekdays(weekdayText); err != nil {
+		return OnCalendarSchedule{}, err
+	}
+	if err = schedule.parseDate(dateText); err != nil {
+		return OnCalendarSchedule{}, err
+	}
+	if timeText, err = schedule.parseTime(timeText); err != nil {
+		return OnCalendarSchedule{}, err
+	}
+	schedule.normalized = strings.TrimSpace(weekdayText + " " + dateText + " " + timeText)
+	return schedule, nil
+}
+
+// MustOnCalendarSchedule is like NewOnCalendarSchedule but panics if the expression is invalid.
+func MustOnCalendarSchedule(expression string) OnCalendarSchedule {
+	schedule, err := NewOnCalendarSchedule(expression)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
+
+// Expression returns the expression the schedule was parsed from.
+func (onCalendar OnCalendarSchedule) Expression() string {
+	return onCalendar.expression
+}
+
+// NextRun returns the next run time after the provided time.
+func (onCalendar OnCalendarSchedule) NextRun(after time.Time) *time.Time {
+	return nextWallClockRun(after, resolveLocation(onCalendar.Location, after), onCalendar.DST, onCalendar.nextMatch)
+}
+
+// nextMatch returns the earliest wall-clock time at or after wallClock that matches every component.
+func (onCalendar OnCalendarSchedule) nextMatch(wallClock time.Time) (time.Time, bool) {
+	yearLimit := min(wallClock.Year()+onCalendarSearchYears, onCalendarYear.maximum)
+	for wallClock.Year() <= yearLimit {
+		switch {
+		case !onCalendar.years.matches(wallClock.Year()):
+			wallClock = time.Date(wallClock.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		case !onCalendar.months.matches(int(wallClock.Month())):
+			wallClock = time.Date(wallClock.Year(), wallClock.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !onCalendar.matchesDay(wallClock):
+			wallClock = time.Date(wallClock.Year(), wallClock.Month(), wallClock.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !onCalendar.hours.matches(wallClock.Hour()):
+			wallClock = wallClock.Truncate(time.Hour).Add(time.Hour)
+		case !onCalendar.minutes.matches(wallClock.Minute()):
+			wallClock = wallClock.Truncate(time.Minute).Add(time.Minute)
+		case !onCalendar.seconds.matches(wallClock.Second()):
+			wallClock = wallClock.Add(time.Second)
+		default:
+			return wallClock, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// matchesDay reports whether both the day of the month and the weekday of the date match.
+func (onCalendar OnCalendarSchedule) matchesDay(wallClock time.Time) bool {
+	day := wallClock.Day()
+	if onCalendar.daysFromEnd {
+		day = daysInMonth(wallClock.Year(), wallClock.Month()) + 1 - day
+	}
+	return onCalendar.days.matches(day) && onCalendar.weekdays.matches(int(wallClock.Weekday()))
+}
+
+// Description returns the normalized expression, such as "OnCalendar=Mon..Fri *-*-* 09:00:00".
+func (onCalendar OnCalendarSchedule) Description() string {
+	return "OnCalendar=" + onCalendar.normalized + describeLocation(onCalendar.Location)
+}
+
+// Validate checks that the schedule was created by NewOnCalendarSchedule.
+func (onCalendar OnCalendarSchedule) Validate() error {
+	if onCalendar.expression == "" {
+		return &ScheduleFieldError{Schedule: "OnCalendarSchedule", Field: "expression", Reason: "is empty; use NewOnCalendarSchedule"}
+	}
+	return nil
+}
+
+// parseDate parses "year-month-day", "month-day" or the "~" forms that count days from the end of the month.
+func (onCalendar *OnCalendarSchedule) parseDate(dateText string) error {
+	separatorIndex := strings.LastIndexAny(dateText, "-~")
+	if separatorIndex < 0 {
+		return fmt.Errorf("%w: invalid date %q", ErrInvalidOnCalendar, dateText)
+	}
+	onCalendar.daysFromEnd = dateText[separatorIndex] == '~'
+	yearMonthText, dayText := dateText[:separatorIndex], dateText[separatorIndex+1:]
+	yearText, monthText, hasYear := strings.Cut(yearMonthText, "-")
+	if !hasYear {
+		yearText, monthText = "*", yearMonthText
+	}
+
+	var err error
+	if onCalendar.years, err = parseOnCalendarField(yearText, onCalendarYear); err != nil {
+		return err
+	}
+	if onCalendar.months, err = parseOnCalendarField(monthText, onCalendarMonth); err != nil {
+		return err
+	}
+	onCalendar.days, err = parseOnCalendarField(dayText, onCalendarDay)
+	return err
+}
+
+// parseTime parses "hour:minute[:second]" and returns it with the seconds filled in.
+func (onCalendar *OnCalendarSchedule) parseTime(timeText string) (string, error) {
+	timeParts := strings.Split(timeText, ":")
+	switch len(timeParts) {
+	case 2:
+		timeParts = append(timeParts, "00")
+	case 3:
+	default:
+		return "", fmt.Errorf("%w: invalid time %q", ErrInvalidOnCalendar, timeText)
+	}
+	var err error
+	if onCalendar.hours, err = parseOnCalendarField(timeParts[0], onCalendarHour); err != nil {
+		return "", err
+	}
+	if onCalendar.minutes, err = parseOnCalendarField(timeParts[1], onCalendarMinute); err != nil {
+		return "", err
+	}
+	if onCalendar.seconds, err = parseOnCalendarField(timeParts[2], onCalendarSecond); err != nil {
+		return "", err
+	}
+	return strings.Join(timeParts, ":"), nil
+}
+
+// parseOnCalendarField parses a list of values, ranges "a..b" and repetitions "a/r" or "a..b/r".
+func parseOnCalendarField(fieldText string, component onCalendarComponent) (onCalendarField, error) {
+	field := onCalendarField{allowed: make([]bool, component.maximum+1), wildcard: fieldText == "*"}
+	if field.wildcard {
+		return field, nil
+	}
+	for _, itemText := range strings.Split(fieldText, ",") {
+		rangeText, repetitionText, hasRepetition := strings.Cut(itemText, "/")
+		repetition := 1
+		if hasRepetition {
+			parsedRepetition, err := strconv.Atoi(repetitionText)
+			if err != nil || parsedRepetition <= 0 {
+				return onCalendarField{}, onCalendarFieldError(component, itemText, "repetition must be a positive number")
+			}
+			repetition = parsedRepetition
+		}
+		start, end := component.minimum, component.maximum
+		switch startText, endText, isRange := strings.Cut(rangeText, ".."); {
+		case rangeText == "*":
+		case isRange:
+			var err error
+			if start, err = parseOnCalendarValue(startText, component); err != nil {
+				return onCalendarField{}, err
+			}
+			if end, err = parseOnCalendarValue(endText, component); err != nil {
+				return onCalendarField{}, err
+			}
+			if end < start {
+				return onCalendarField{}, onCalendarFieldError(component, itemText, "range end is before range start")
+			}
+		default:
+			value, err := parseOnCalendarValue(rangeText, component)
+			if err != nil {
+				return onCalendarField{}, err
+			}
+			start = value
+			if !hasRepetition {
+				end = value
+			}
+		}
+		for value := start; value <= end; value += repetition {
+			field.allowed[value] = true
+		}
+	}
+	return field, nil
+}
+
+// parseOnCalendarValue parses a number and checks it against the component bounds.
+func parseOnCalendarValue(valueText string, component onCalendarComponent) (int, error) {
+	value, err := strconv.Atoi(valueText)
+	if err != nil {
+		return 0, onCalendarFieldError(component, valueText, "not a number")
+	}
+	if value < component.minimum || value > component.maximum {
+		return 0, onCalendarFieldError(component, valueText, fmt.Sprintf("value must be between %d and %d", component.minimum, component.maximum))
+	}
+	return value, nil
+}
+
+// parseOnCalendarWeekdays parses weekday names, lists and ".." ranges; "" means every day.
+func parseOnCalendarWeekdays(weekdayText string) (onCalendarField, error) {
+	field := onCalendarField{allowed: make([]bool, 7), wildcard: weekdayText == ""}
+	if field.wildcard {
+		return field, nil
+	}
+	for _, itemText := range strings.Split(weekdayText, ",") {
+		startText, endText, isRange := strings.Cut(itemText, "..")
+		if !isRange {
+			endText = startText
+		}
+		start, startExists := scheduleWeekdayNames[strings.ToLower(startText)]
+		end, endExists := scheduleWeekdayNames[strings.ToLower(endText)]
+		if !startExists || !endExists {
+			return onCalendarField{}, fmt.Errorf("%w: unknown weekday in %q", ErrInvalidOnCalendar, itemText)
+		}
+		// Ranges may wrap around the end of the week, as in "Sat..Mon".
+		for weekday := start; ; weekday = (weekday + 1) % 7 {
+			field.allowed[weekday] = true
+			if weekday == end {
+				break
+			}
+		}
+	}
+	return field, nil
+}
+
+// isOnCalendarWeekdays reports whether the text looks like a weekday specification.
+func isOnCalendarWeekdays(text string) bool {
+	firstName, _, _ := strings.Cut(text, ",")
+	firstName, _, _ = strings.Cut(firstName, "..")
+	_, exists := scheduleWeekdayNames[strings.ToLower(firstName)]
+	return exists
+}
+
+// isOnCalendarDate reports whether the text looks like a date rather than a time or a time zone.
+func isOnCalendarDate(text string) bool {
+	if strings.Contains(text, ":") || !strings.ContainsAny(text, "-~") {
+		return false
+	}
+	// Time zone names such as "America/Port-au-Prince" contain letters; dates do not.
+	return strings.Trim(text, "0123456789*-~.,/") == ""
+}
+
+// onCalendarFieldError builds an ErrInvalidOnCalendar error that names the offending component.
+func onCalendarFieldError(component onCalendarComponent, text string, reason string) error {
+	return fmt.Errorf("%w: %s %q: %s", ErrInvalidOnCalendar, component.name, text, reason)
+}
@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TimeoutTask is an optional interface for tasks that need a Run timeout other than the
+// scheduler's default. The timeout applies to each attempt; zero disables it.
+type TimeoutTask interface {
+	Task
+	Timeout() time.Duration
+}
+
+// BeforeExecuteTimeoutTask is an optional interface for tasks that need a BeforeExecute timeout
+// other than the scheduler's default. Zero disables it.
+type BeforeExecuteTimeoutTask interface {
+	Task
+	BeforeExecuteTimeout() time.Duration
+}
+
+// DeadlineTask is an optional interface for tasks whose whole run, BeforeExecute and every
+// attempt of Run, must finish by a point in time, such as before the next run is due. No retry
+// is started once the deadline has passed. A zero deadline means none.
+type DeadlineTask interface {
+	Task
+	Deadline(scheduledAt time.Time) time.Time
+}
+
+// TimeoutError reports a BeforeExecute or Run call that failed because its context expired.
+// It wraps both ErrTaskTimeout and the error returned by the task.
+type TimeoutError struct {
+	TaskID   string
+	Phase    string        // "BeforeExecute" or "Run".
+	Timeout  time.Duration // Timeout of the call; zero if the run's deadline expired first.
+	Deadline time.Time     // Deadline of the run; zero if the call's timeout expired first.
+	Err      error         // Error returned by the task.
+}
+
+func (timeoutError *TimeoutError) Error() string {
+	if !timeoutError.Deadline.IsZero() {
+		return fmt.Sprintf("task %q: %s missed its deadline %s: %v", timeoutError.TaskID, timeoutError.Phase, timeoutError.Deadline.Format(time.RFC3339), timeoutError.Err)
+	}
+	return fmt.Sprintf("task %q: %s timed out after %s: %v", timeoutError.TaskID, timeoutError.Phase, timeoutError.Timeout, timeoutError.Err)
+}
+
+func (timeoutError *TimeoutError) Unwrap() []error {
+	return []error{ErrTaskTimeout, timeoutError.Err}
+}
+
+// runTimeoutFor returns the Run timeout of the task: its own, or the scheduler's default.
+func (schedulerInstance *Scheduler) runTimeoutFor(taskInstance Task) time.Duration {
+	if timeoutTask, hasTimeout := taskInstance.(TimeoutTask); hasTimeout {
+		return timeoutTask.Timeout()
+	}
+	return schedulerInstance.runTimeout
+}
+
+// beforeExecuteTimeoutFor returns the BeforeExecute timeout of the task: its own, or the scheduler's default.
+func (schedulerInstance *Scheduler) beforeExecuteTimeoutFor(taskInstance Task) time.Duration {
+	if timeoutTask, hasTimeout := taskInstance.(BeforeExecuteTimeoutTask); hasTimeout {
+		return timeoutTask.BeforeExecuteTimeout()
+	}
+	return schedulerInstance.beforeExecuteTimeout
+}
+
+// deadlineFor returns the deadline of the task's run scheduled at scheduledAt, or zero if it has none.
+func deadlineFor(taskInstance Task, scheduledAt time.Time) time.Time {
+	if deadlineTask, hasDeadline := taskInstance.(DeadlineTask); hasDeadline {
+		return deadlineTask.Deadline(scheduledAt)
+	}
+	return time.Time{}
+}
+
+// withRunDeadline derives the context shared by all calls of a run, which expires at deadline
+// as told by the scheduler's clock. A zero deadline never expires.
+func (schedulerInstance *Scheduler) withRunDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(parent)
+	}
+	return withClockTimeout(parent, schedulerInstance.clock, deadline.Sub(schedulerInstance.clock.Now()))
+}
+
+// callWithTimeout calls call with a context derived from runContext that also expires once the
+// scheduler's clock has advanced by timeout, and wraps its error in a TimeoutError if that
+// context expired.
+func (schedulerInstance *Scheduler) callWithTimeout(taskInstance Task, phase string, runContext context.Context, deadline time.Time, timeout time.Duration, call func(ctx context.Context) error) error {
+	callContext, cancelCall := context.WithCancel(runContext)
+	if timeout > 0 {
+		callContext, cancelCall = withClockTimeout(runContext, schedulerInstance.clock, timeout)
+	}
+	defer cancelCall()
+
+	callError := call(callContext)
+	if callError == nil || !errors.Is(callContext.Err(), context.DeadlineExceeded) {
+		return callError
+	}
+	timeoutError := &TimeoutError{TaskID: taskInstance.ID(), Phase: phase, Timeout: timeout, Err: callError}
+	if errors.Is(runContext.Err(), context.DeadlineExceeded) {
+		timeoutError.Timeout, timeoutError.Deadline = 0, deadline
+	}
+	return timeoutError
+}
+
+// withClockTimeout derives a context that expires once clock has advanced by timeout. Under the
+// system clock it is context.WithTimeout; under another Clock, such as a FakeClock, it waits on a
+// timer of that clock, so that advancing the clock expires it.
+func withClockTimeout(parent context.Context, clock Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, isSystemClock := clock.(systemClock); isSystemClock {
+		return context.WithTimeout(parent, timeout)
+	}
+	timeoutContext := &clockTimeoutContext{parent: parent, deadline: clock.Now().Add(timeout), done: make(chan struct{})}
+	timer := clock.NewTimer(timeout)
+	go func() {
+		select {
+		case <-timer.C():
+			timeoutContext.cancel(context.DeadlineExceeded)
+		case <-parent.Done():
+			timer.Stop()
+			timeoutContext.cancel(parent.Err())
+		case <-timeoutContext.done:
+			timer.Stop()
+		}
+	}()
+	// A standard context on top, so that context.Cause agrees with Err.
+	derivedContext, cancelDerived := context.WithCancel(timeoutContext)
+	return derivedContext, func() {
+		cancelDerived()
+		timeoutContext.cancel(context.Canceled)
+	}
+}
+
+// clockTimeoutContext expires withClockTimeout contexts for clocks other than the system clock.
+// It has its own Done channel, so that contexts derived from it see its Err.
+type clockTimeoutContext struct {
+	parent   context.Context
+	deadline time.Time
+	done     chan struct{}
+	mutex    sync.Mutex
+	err      error
+}
+
+func (timeoutContext *clockTimeoutContext) Deadline() (time.Time, bool) {
+	if parentDeadline, hasDeadline := timeoutContext.parent.Deadline(); hasDeadline && parentDeadline.Before(timeoutContext.deadline) {
+		return parentDeadline, true
+	}
+	return timeoutContext.deadline, true
+}
+
+func (timeoutContext *clockTimeoutContext) Done() <-chan struct{} {
+	return timeoutContext.done
+}
+
+func (timeoutContext *clockTimeoutContext) Err() error {
+	timeoutContext.mutex.Lock()
+	defer timeoutContext.mutex.Unlock()
+
+	return timeoutContext.err
+}
+
+func (timeoutContext *clockTimeoutContext) Value(key interface{}) interface{} {
+	return timeoutContext.parent.Value(key)
+}
+
+// cancel closes Done with err, unless the context is already done.
+func (timeoutContext *clockTimeoutContext) cancel(err error) {
+	timeoutContext.mutex.Lock()
+	defer timeoutContext.mutex.Unlock()
+
+	if timeoutContext.err == nil {
+		timeoutContext.err = err
+		close(timeoutContext.done)
+	}
+}
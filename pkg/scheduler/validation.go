@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleValidator is an optional interface for schedules and windows that can check their
+// configuration. RegisterTaskInfo, Scheduler.RegisterTask and UnmarshalScheduleJSON call it.
+type ScheduleValidator interface {
+	Validate() error
+}
+
+// ScheduleFieldError describes an invalid field of a schedule. It wraps ErrInvalidSchedule.
+type ScheduleFieldError struct {
+	Schedule string // Type of the schedule, such as "DailySchedule".
+	Field    string
+	Reason   string
+}
+
+// Error returns a message such as "invalid schedule: DailySchedule.Hour must be between 0 and 23, got 25".
+func (fieldError *ScheduleFieldError) Error() string {
+	return fmt.Sprintf("%v: %s.%s %s", ErrInvalidSchedule, fieldError.Schedule, fieldError.Field, fieldError.Reason)
+}
+
+// Unwrap returns ErrInvalidSchedule.
+func (fieldError *ScheduleFieldError) Unwrap() error {
+	return ErrInvalidSchedule
+}
+
+// ValidateSchedule checks a schedule before it is used: nil is rejected and schedules
+// implementing ScheduleValidator are asked to validate themselves.
+func ValidateSchedule(schedule TimeSchedule) error {
+	if schedule == nil {
+		return fmt.Errorf("%w: schedule is nil", ErrInvalidSchedule)
+	}
+	if validator, ok := schedule.(ScheduleValidator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// validateNested validates a schedule held in a field of another schedule.
+func validateNested(scheduleType string, field string, schedule TimeSchedule) error {
+	if schedule == nil {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: field, Reason: "is required"}
+	}
+	if err := ValidateSchedule(schedule); err != nil {
+		return fmt.Errorf("%s.%s: %w", scheduleType, field, err)
+	}
+	return nil
+}
+
+// validateScheduleList validates the schedules of a composite schedule, which must not be empty.
+func validateScheduleList(scheduleType string, schedules []TimeSchedule) error {
+	if len(schedules) == 0 {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: "Schedules", Reason: "must not be empty"}
+	}
+	for index, schedule := range schedules {
+		if err := validateNested(scheduleType, fmt.Sprintf("Schedules[%d]", index), schedule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateWindows validates the windows of a composite schedule.
+func validateWindows(scheduleType string, windows []Window) error {
+	for index, window := range windows {
+		field := fmt.Sprintf("Windows[%d]", index)
+		if window == nil {
+			return &ScheduleFieldError{Schedule: scheduleType, Field: field, Reason: "is nil"}
+		}
+		if validator, ok := window.(ScheduleValidator); ok {
+			if err := validator.Validate(); err != nil {
+				return fmt.Errorf("%s.%s: %w", scheduleType, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRange reports a field error unless minimum <= value <= maximum.
+func validateRange(scheduleType string, field string, value int, minimum int, maximum int) error {
+	if value < minimum || value > maximum {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: field, Reason: fmt.Sprintf("must be between %d and %d, got %d", minimum, maximum, value)}
+	}
+	return nil
+}
+
+// validateTimeOfDay checks that hour and minute form a valid time of day.
+func validateTimeOfDay(scheduleType string, hour int, minute int) error {
+	if err := validateRange(scheduleType, "Hour", hour, 0, 23); err != nil {
+		return err
+	}
+	return validateRange(scheduleType, "Minute", minute, 0, 59)
+}
+
+// validateWeekdays checks that weekdays is non-empty and holds only valid days.
+func validateWeekdays(scheduleType string, field string, weekdays []time.Weekday) error {
+	if len(weekdays) == 0 {
+		return &ScheduleFieldError{Schedule: scheduleType, Field: field, Reason: "must not be empty"}
+	}
+	for _, weekday := range weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return &ScheduleFieldError{Schedule: scheduleType, Field: field, Reason: fmt.Sprintf("holds invalid weekday %d", weekday)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestBoundedScheduleDateRange(t *testing.T) {
+	schedule := scheduler.BoundedSchedule{
+		Schedule:  scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		NotBefore: time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC),
+	}
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), 5)
+	assertRunTimes(t, "date range", runTimes, []time.Time{
+		time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestBoundedScheduleMaxRuns(t *testing.T) {
+	schedule := scheduler.BoundedSchedule{
+		Schedule:  scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		NotBefore: time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC),
+		MaxRuns:   3,
+	}
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), 5)
+	assertRunTimes(t, "max runs", runTimes, []time.Time{
+		time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 13, 9, 0, 0, 0, time.UTC),
+	})
+
+	testCases := []struct {
+		after             time.Time
+		expectedRemaining int
+		expectedNote      string
+	}{
+		{time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), 3, "3 runs remaining"},
+		{time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC), 1, "last run"},
+		{time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC), 0, "0 runs remaining"},
+	}
+	for _, testCase := range testCases {
+		if remainingRuns := schedule.RemainingRuns(testCase.after); remainingRuns != testCase.expectedRemaining {
+			t.Errorf("After %v: expected %d runs remaining, got %d", testCase.after, testCase.expectedRemaining, remainingRuns)
+		}
+		if note := schedule.ExplainNextRun(testCase.after); note != testCase.expectedNote {
+			t.Errorf("After %v: expected note %q, got %q", testCase.after, testCase.expectedNote, note)
+		}
+	}
+}
+
+func TestBoundedScheduleMaxRunsOutOfOrder(t *testing.T) {
+	notBefore := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	for _, innerSchedule := range []scheduler.TimeSchedule{
+		scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		scheduler.AnyOf(scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}),
+	} {
+		schedule := scheduler.BoundedSchedule{Schedule: innerSchedule, NotBefore: notBefore, MaxRuns: 3}
+		// Stepping to the end first must not change the counts for earlier times.
+		collectRuns(schedule, notBefore, 5)
+		if remainingRuns := schedule.RemainingRuns(time.Date(2026, time.March, 11, 9, 0, 0, 0, time.UTC)); remainingRuns != 1 {
+			t.Errorf("%s: expected 1 run remaining, got %d", innerSchedule.Description(), remainingRuns)
+		}
+		if nextRunTime := schedule.NextRun(time.Date(2026, time.March, 12, 9, 0, 0, 0, time.UTC)); nextRunTime != nil {
+			t.Errorf("%s: expected no run after the last one, got %v", innerSchedule.Description(), *nextRunTime)
+		}
+		if nextRunTime := schedule.NextRun(notBefore); nextRunTime == nil || !nextRunTime.Equal(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)) {
+			t.Errorf("%s: expected the first run again, got %v", innerSchedule.Description(), nextRunTime)
+		}
+	}
+}
+
+func TestBoundedScheduleMaxRunsRequiresNotBefore(t *testing.T) {
+	schedule := scheduler.BoundedSchedule{Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0}, MaxRuns: 2}
+	if nextRunTime := schedule.NextRun(time.Now()); nextRunTime != nil {
+		t.Errorf("Expected no run without NotBefore, got %v", *nextRunTime)
+	}
+
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	limited := scheduler.LimitRuns(scheduler.IntervalSchedule{Interval: time.Hour, StartTime: fakeClock.Now().Add(time.Hour)}, fakeClock.Now(), 2)
+	if remainingRuns := limited.RemainingRuns(fakeClock.Now()); remainingRuns != 2 {
+		t.Errorf("Expected 2 runs remaining, got %d", remainingRuns)
+	}
+	if !limited.NotBefore.Equal(fakeClock.Now()) {
+		t.Errorf("Expected the runs to be counted from the clock's time, got %v", limited.NotBefore)
+	}
+}
+
+func TestBoundedScheduleNotes(t *testing.T) {
+	schedule := scheduler.BoundedSchedule{
+		Schedule: scheduler.BusinessDaySchedule{
+			Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+			Calendar: scheduler.HolidayCalendar{},
+			Policy:   scheduler.BusinessDayShiftForward,
+		},
+		NotAfter: time.Date(2026, time.March, 31, 23, 59, 0, 0, time.UTC),
+	}
+
+	note := schedule.ExplainNextRun(time.Date(2026, time.March, 7, 12, 0, 0, 0, time.UTC))
+	expected := "shifted from Sat, Mar 7 (Saturday); ends on Mar 31, 2026"
+	if note != expected {
+		t.Errorf("Expected note %q, got %q", expected, note)
+	}
+
+	expectedDescription := "Daily at 09:00 (UTC), moved to the next business day on holidays and weekends, until 2026-03-31 23:59"
+	if description := schedule.Description(); description != expectedDescription {
+		t.Errorf("Expected description %q, got %q", expectedDescription, description)
+	}
+}
+
+func TestSchedulerStopsBoundedScheduleAfterMaxRuns(t *testing.T) {
+	startTime := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	fakeClock := scheduler.NewFakeClock(startTime)
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock))
+	task := NewTestTask("bounded-task", scheduler.BoundedSchedule{
+		Schedule:  scheduler.IntervalSchedule{Interval: time.Minute, StartTime: startTime},
+		NotBefore: startTime.Add(time.Second),
+		MaxRuns:   2,
+	})
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	for runIndex := int32(1); runIndex <= 2; runIndex++ {
+		waitForTimers(t, fakeClock, 1)
+		fakeClock.Advance(time.Minute)
+		waitForCondition(t, "the bounded run", func() bool { return task.GetExecutionCount() == runIndex })
+	}
+	time.Sleep(20 * time.Millisecond)
+	if pendingTimers := fakeClock.PendingTimers(); len(pendingTimers) != 0 {
+		t.Errorf("Expected no run after the last one, got timers at %v", pendingTimers)
+	}
+}
@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func newTestHolidayCalendar() scheduler.HolidayCalendar {
+	return scheduler.HolidayCalendar{
+		Holidays: []scheduler.Holiday{
+			{Date: time.Date(2026, time.December, 25, 0, 0, 0, 0, time.UTC), Name: "Christmas Day"},
+			{Date: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "New Year's Day"},
+		},
+		ExcludedDates: []time.Time{time.Date(2026, time.December, 29, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestHolidayCalendarIsBusinessDay(t *testing.T) {
+	calendar := newTestHolidayCalendar()
+
+	testCases := []struct {
+		date           time.Time
+		expectedResult bool
+		expectedReason string
+	}{
+		{time.Date(2026, time.December, 24, 0, 0, 0, 0, time.UTC), true, ""},
+		{time.Date(2026, time.December, 25, 0, 0, 0, 0, time.UTC), false, "Christmas Day"},
+		{time.Date(2026, time.December, 26, 0, 0, 0, 0, time.UTC), false, "Saturday"},
+		{time.Date(2026, time.December, 29, 0, 0, 0, 0, time.UTC), false, "excluded date"},
+	}
+
+	for _, testCase := range testCases {
+		isBusinessDay, reason := calendar.IsBusinessDay(testCase.date)
+		if isBusinessDay != testCase.expectedResult || reason != testCase.expectedReason {
+			t.Errorf("Date %s: expected (%v, %q), got (%v, %q)", testCase.date.Format("2006-01-02"),
+				testCase.expectedResult, testCase.expectedReason, isBusinessDay, reason)
+		}
+	}
+
+	sevenDayCalendar := scheduler.HolidayCalendar{Weekend: []time.Weekday{}}
+	if isBusinessDay, _ := sevenDayCalendar.IsBusinessDay(time.Date(2026, time.December, 26, 0, 0, 0, 0, time.UTC)); !isBusinessDay {
+		t.Errorf("Expected Saturday to be a business day with an empty weekend")
+	}
+}
+
+func TestBusinessDayScheduleSkip(t *testing.T) {
+	schedule := scheduler.BusinessDaySchedule{
+		Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		Calendar: newTestHolidayCalendar(),
+		Policy:   scheduler.BusinessDaySkip,
+	}
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.December, 23, 12, 0, 0, 0, time.UTC), 4)
+	assertRunTimes(t, "skip", runTimes, []time.Time{
+		time.Date(2026, time.December, 24, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.December, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.December, 30, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.December, 31, 9, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestBusinessDayScheduleShiftForward(t *testing.T) {
+	monthlySchedule := scheduler.MonthlySchedule{Days: []int{25}, Hour: 8, Minute: 30, Location: time.UTC}
+	schedule := scheduler.BusinessDaySchedule{
+		Schedule: monthlySchedule,
+		Calendar: newTestHolidayCalendar(),
+		Policy:   scheduler.BusinessDayShiftForward,
+	}
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC), 2)
+	assertRunTimes(t, "shift forward", runTimes, []time.Time{
+		time.Date(2026, time.December, 28, 8, 30, 0, 0, time.UTC),
+		time.Date(2027, time.January, 25, 8, 30, 0, 0, time.UTC),
+	})
+
+	// A run shifted off the weekend is still due after the weekend itself has started.
+	nextRunTime := schedule.NextRun(time.Date(2026, time.December, 26, 12, 0, 0, 0, time.UTC))
+	expected := time.Date(2026, time.December, 28, 8, 30, 0, 0, time.UTC)
+	if nextRunTime == nil || !nextRunTime.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, nextRunTime)
+	}
+}
+
+func TestBusinessDayScheduleShiftForwardMergesRuns(t *testing.T) {
+	schedule := scheduler.BusinessDaySchedule{
+		Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		Calendar: scheduler.HolidayCalendar{},
+		Policy:   scheduler.BusinessDayShiftForward,
+	}
+
+	runTimes := collectRuns(schedule, time.Date(2027, time.January, 8, 12, 0, 0, 0, time.UTC), 2)
+	assertRunTimes(t, "merged weekend runs", runTimes, []time.Time{
+		time.Date(2027, time.January, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2027, time.January, 12, 9, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestBusinessDayScheduleShiftBackward(t *testing.T) {
+	schedule := scheduler.BusinessDaySchedule{
+		Schedule: scheduler.MonthlySchedule{Days: []int{1}, Hour: 6, Minute: 0, Location: time.UTC},
+		Calendar: newTestHolidayCalendar(),
+		Policy:   scheduler.BusinessDayShiftBackward,
+	}
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.December, 15, 0, 0, 0, 0, time.UTC), 2)
+	assertRunTimes(t, "shift backward", runTimes, []time.Time{
+		time.Date(2026, time.December, 31, 6, 0, 0, 0, time.UTC),
+		time.Date(2027, time.February, 1, 6, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestBusinessDayScheduleExplainNextRun(t *testing.T) {
+	forwardSchedule := scheduler.BusinessDaySchedule{
+		Schedule: scheduler.MonthlySchedule{Days: []int{25}, Hour: 8, Minute: 30, Location: time.UTC},
+		Calendar: newTestHolidayCalendar(),
+		Policy:   scheduler.BusinessDayShiftForward,
+	}
+	note := forwardSchedule.ExplainNextRun(time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC))
+	if note != "shifted from Fri, Dec 25 (Christmas Day)" {
+		t.Errorf("Unexpected note for a shifted run: %q", note)
+	}
+	if note := forwardSchedule.ExplainNextRun(time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)); note != "" {
+		t.Errorf("Expected no note for an unshifted run, got %q", note)
+	}
+
+	skipSchedule := scheduler.BusinessDaySchedule{
+		Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		Calendar: newTestHolidayCalendar(),
+	}
+	note = skipSchedule.ExplainNextRun(time.Date(2026, time.December, 24, 12, 0, 0, 0, time.UTC))
+	if note != "skipping Fri, Dec 25 (Christmas Day)" {
+		t.Errorf("Unexpected note for a skipped run: %q", note)
+	}
+}
+
+func TestBusinessDayScheduleDescription(t *testing.T) {
+	schedule := scheduler.BusinessDaySchedule{
+		Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0},
+		Calendar: scheduler.HolidayCalendar{},
+		Policy:   scheduler.BusinessDayShiftForward,
+	}
+
+	expected := "Daily at 09:00, moved to the next business day on holidays and weekends"
+	if description := schedule.Description(); description != expected {
+		t.Errorf("Expected description %q, got %q", expected, description)
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	holidayList := `# Company holidays 2026
+2026-12-25 Christmas Day
+
+2026-12-31
+`
+	holidays, err := scheduler.ParseHolidays(strings.NewReader(holidayList))
+	if err != nil {
+		t.Fatalf("Failed to parse holidays: %v", err)
+	}
+	if len(holidays) != 2 {
+		t.Fatalf("Expected 2 holidays, got %d", len(holidays))
+	}
+	if holidays[0].Name != "Christmas Day" || !holidays[0].Date.Equal(time.Date(2026, time.December, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first holiday: %+v", holidays[0])
+	}
+	if holidays[1].Name != "holiday" {
+		t.Errorf("Expected a default name for an unnamed holiday, got %q", holidays[1].Name)
+	}
+
+	_, err = scheduler.ParseHolidays(strings.NewReader("2026-12-25 Christmas Day\n25/12/2026 Boxing Day\n"))
+	if !errors.Is(err, scheduler.ErrInvalidHolidayList) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected ErrInvalidHolidayList naming line 2, got: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// waitForCondition polls condition until it holds or a second has passed.
+func waitForCondition(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForTimers waits until the fake clock has count pending timers.
+func waitForTimers(t *testing.T, fakeClock *scheduler.FakeClock, count int) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if !fakeClock.WaitForTimers(ctx, count) {
+		t.Fatalf("Expected %d pending timers, got %v", count, fakeClock.PendingTimers())
+	}
+}
+
+func TestFakeClockTimers(t *testing.T) {
+	start := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	fakeClock := scheduler.NewFakeClock(start)
+	late := fakeClock.NewTimer(2 * time.Minute)
+	early := fakeClock.NewTimer(time.Minute)
+	stopped := fakeClock.NewTimer(time.Minute)
+
+	if !stopped.Stop() || stopped.Stop() {
+		t.Errorf("Expected Stop to report a pending timer only the first time")
+	}
+	pendingTimers := fakeClock.PendingTimers()
+	if len(pendingTimers) != 2 || !pendingTimers[0].Equal(start.Add(time.Minute)) {
+		t.Fatalf("Unexpected pending timers %v", pendingTimers)
+	}
+
+	fakeClock.Advance(90 * time.Second)
+	select {
+	case firedAt := <-early.C():
+		if !firedAt.Equal(start.Add(time.Minute)) {
+			t.Errorf("Expected the timer to deliver its deadline, got %v", firedAt)
+		}
+	default:
+		t.Fatalf("Expected the one-minute timer to fire")
+	}
+	select {
+	case <-late.C():
+		t.Fatalf("Expected the two-minute timer to still be pending")
+	default:
+	}
+	if now := fakeClock.Now(); !now.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("Expected the clock to read %v, got %v", start.Add(90*time.Second), now)
+	}
+
+	sleeping := make(chan struct{})
+	go func() {
+		fakeClock.Sleep(time.Hour)
+		close(sleeping)
+	}()
+	waitForTimers(t, fakeClock, 2)
+	fakeClock.Advance(time.Hour)
+	<-sleeping
+	<-late.C()
+}
+
+func TestSchedulerWithFakeClockRunsIntervals(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock))
+	testTask := NewTestTask("fake-clock-interval", scheduler.IntervalSchedule{Interval: time.Hour})
+	if err := schedulerInstance.RegisterTask(testTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	// A day of hourly runs takes no real time.
+	for hour := 1; hour <= 24; hour++ {
+		waitForTimers(t, fakeClock, 1)
+		fakeClock.Advance(time.Hour)
+		waitForCondition(t, "the hourly run", func() bool { return testTask.GetExecutionCount() == int32(hour) })
+	}
+}
+
+// failingTask fails every run.
+type failingTask struct {
+	*TestTask
+}
+
+func (task failingTask) Run(ctx context.Context) error {
+	task.TestTask.Run(ctx)
+	return errors.New("run failed")
+}
+
+func TestRunTaskNowWaitsForRetryDelaysOnTheClock(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock))
+	testTask := failingTask{NewTestTask("fake-clock-retry", scheduler.DailySchedule{Hour: 3})}
+	if err := schedulerInstance.RegisterTask(testTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	runResult := make(chan error, 1)
+	go func() {
+		runResult <- schedulerInstance.RunTaskNow(testTask.ID())
+	}()
+
+	// The retry waits for its delay on the fake clock.
+	waitForTimers(t, fakeClock, 1)
+	if executionCount := testTask.GetExecutionCount(); executionCount != 1 {
+		t.Fatalf("Expected one attempt before the retry delay, got %d", executionCount)
+	}
+	fakeClock.Advance(100 * time.Millisecond)
+	if err := <-runResult; err == nil {
+		t.Errorf("Expected the failing task to return an error")
+	}
+	if executionCount := testTask.GetExecutionCount(); executionCount != 2 {
+		t.Errorf("Expected a retry after the delay, got %d attempts", executionCount)
+	}
+}
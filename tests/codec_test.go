@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestScheduleJSONRoundTrip(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	ruleSchedule, err := scheduler.NewRRuleSchedule("FREQ=MONTHLY;BYDAY=2TU", time.Date(2026, time.January, 13, 9, 0, 0, 0, berlin))
+	if err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+	ruleSchedule.ExDates = []time.Time{time.Date(2026, time.February, 10, 9, 0, 0, 0, berlin)}
+	referenceTime := time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)
+
+	schedules := []scheduler.TimeSchedule{
+		scheduler.DailySchedule{Hour: 9, Minute: 30, Location: berlin, DST: scheduler.DSTPolicy{Gap: scheduler.DSTGapSkip}},
+		scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday, time.Friday}, Hour: 18, Minute: 0},
+		scheduler.MonthlySchedule{Days: []int{31, -1}, NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Tuesday, N: 2}}, ShortMonths: scheduler.ShortMonthClamp, Hour: 7, Minute: 5},
+		scheduler.IntervalSchedule{Interval: 15 * time.Minute, StartTime: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		scheduler.MustCronSchedule("CRON_TZ=Europe/Berlin 0 9 * * MON-FRI"),
+		ruleSchedule,
+		scheduler.BoundedSchedule{
+			Schedule:  scheduler.DailySchedule{Hour: 6, Minute: 0, Location: time.UTC},
+			NotBefore: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+			MaxRuns:   40,
+		},
+		scheduler.WithJitter(scheduler.DailySchedule{Hour: 0, Minute: 0, Location: time.UTC}, 10*time.Minute, "seed"),
+		scheduler.AnyOf(scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}, scheduler.MustCronSchedule("30 17 * * *")),
+		scheduler.AllOf(scheduler.MustCronSchedule("0 9 13 * *"), scheduler.MustCronSchedule("0 9 * * FRI")),
+		scheduler.Within(scheduler.MustCronSchedule("*/30 * * * *"), scheduler.Between(8*time.Hour, 17*time.Hour).On(time.Monday, time.Friday).In(berlin)),
+		scheduler.Except(
+			scheduler.MustCronSchedule("0 * * * *"),
+			scheduler.ScheduleWindow{Schedule: scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Sunday}, Hour: 2, Minute: 0, Location: time.UTC}, Duration: 2 * time.Hour},
+			scheduler.DateRangeWindow{Start: time.Date(2026, time.January, 21, 0, 0, 0, 0, time.UTC), End: time.Date(2026, time.January, 22, 12, 0, 0, 0, time.UTC)},
+		),
+		scheduler.BusinessDaySchedule{
+			Schedule: scheduler.MustCronSchedule("0 9 24,25 * *"),
+			Calendar: scheduler.HolidayCalendar{
+				Weekend:       []time.Weekday{time.Friday, time.Saturday},
+				Holidays:      []scheduler.Holiday{{Date: time.Date(2026, time.February, 24, 0, 0, 0, 0, time.UTC), Name: "Founding Day"}},
+				ExcludedDates: []time.Time{time.Date(2026, time.March, 24, 0, 0, 0, 0, time.UTC)},
+			},
+			Policy:   scheduler.BusinessDayShiftForward,
+			Location: berlin,
+		},
+		scheduler.BusinessDaySchedule{Schedule: scheduler.DailySchedule{Hour: 7, Minute: 0, Location: time.UTC}, Calendar: scheduler.HolidayCalendar{Weekend: []time.Weekday{}}},
+		scheduler.RandomTimeWithin(scheduler.Between(time.Hour, 5*time.Hour), "seed"),
+		scheduler.AnyOf(scheduler.Within(scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}, scheduler.Between(0, 12*time.Hour).On(time.Tuesday))),
+	}
+
+	for _, original := range schedules {
+		data, err := scheduler.MarshalScheduleJSON(original)
+		if err != nil {
+			t.Errorf("Failed to marshal %T: %v", original, err)
+			continue
+		}
+		decoded, err := scheduler.UnmarshalScheduleJSON(data)
+		if err != nil {
+			t.Errorf("Failed to unmarshal %s: %v", data, err)
+			continue
+		}
+		if reflect.TypeOf(decoded) != reflect.TypeOf(original) {
+			t.Errorf("Expected %T after round trip, got %T", original, decoded)
+		}
+		if decoded.Description() != original.Description() {
+			t.Errorf("Description changed from %q to %q", original.Description(), decoded.Description())
+		}
+		assertRunTimes(t, string(data), collectRuns(decoded, referenceTime, 5), collectRuns(original, referenceTime, 5))
+	}
+}
+
+func TestScheduleJSONFormat(t *testing.T) {
+	data, err := scheduler.MarshalScheduleJSON(scheduler.DailySchedule{Hour: 9, Minute: 30, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	expected := `{"type":"daily","hour":9,"minute":30,"location":"UTC"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	oneTime, err := scheduler.UnmarshalScheduleJSON([]byte(`{"type": "once", "run_time": "2026-11-01T10:00:00Z"}`))
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if runTime := oneTime.(*scheduler.OneTimeSchedule).RunTime(); !runTime.Equal(time.Date(2026, time.November, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected run time %v", runTime)
+	}
+}
+
+func TestScheduleJSONValidationErrors(t *testing.T) {
+	testCases := []struct {
+		document      string
+		expectedError error
+	}{
+		{`{"type": "hourly"}`, scheduler.ErrUnknownScheduleType},
+		{`{"hour": 9}`, scheduler.ErrInvalidScheduleData},
+		{`[1, 2]`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "daily", "hour": 24, "minute": 0}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "daily", "hour": 9, "minute": 0, "location": "Mars/Olympus"}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "daily", "hour": 9, "minute": 0, "dst": {"gap": "sometimes"}}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "daily", "hour": 9, "minute": 0, "weekdays": ["monday"]}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "weekday", "weekdays": [], "hour": 9, "minute": 0}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "weekday", "weekdays": ["funday"], "hour": 9, "minute": 0}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "monthly", "days": [32], "hour": 9, "minute": 0}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "interval", "interval": "0s"}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "interval", "interval": "fortnight"}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "once"}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "cron", "expression": "61 * * * *"}`, scheduler.ErrInvalidCronExpression},
+		{`{"type": "bounded", "schedule": {"type": "interval", "interval": "1h"}, "max_runs": 3}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "any_of", "schedules": [{"type": "nope"}]}`, scheduler.ErrUnknownScheduleType},
+		{`{"type": "all_of", "schedules": []}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "within", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "windows": [{"type": "lunar"}]}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "within", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "windows": [{"type": "time_of_day", "start": "25h", "end": "1h"}]}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "within", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "windows": [{"type": "time_of_day", "start": "1h", "end": "2h", "duration": "1h"}]}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "except", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "windows": [{"type": "date_range", "from": "2026-01-01T00:00:00Z"}]}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "except", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "windows": [{"type": "schedule", "schedule": {"type": "daily", "hour": 2, "minute": 0}, "duration": "-1h"}]}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "except", "schedule": {"type": "nope"}, "windows": []}`, scheduler.ErrUnknownScheduleType},
+		{`{"type": "business_day", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "calendar": {"holidays": [{"date": "12/25/2026"}]}}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "business_day", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "calendar": {}, "policy": "sideways"}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "business_day", "schedule": {"type": "daily", "hour": 9, "minute": 0}, "calendar": {"weekend": ["sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"]}}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "random_window", "seed": "host"}`, scheduler.ErrInvalidScheduleData},
+		{`{"type": "random_window", "window": {"type": "time_of_day", "start": "1h", "end": "5h", "extra": true}}`, scheduler.ErrInvalidScheduleData},
+	}
+
+	for _, testCase := range testCases {
+		_, err := scheduler.UnmarshalScheduleJSON([]byte(testCase.document))
+		if !errors.Is(err, testCase.expectedError) {
+			t.Errorf("Document %s: expected %v, got %v", testCase.document, testCase.expectedError, err)
+		}
+	}
+}
+
+// quarterHourSchedule is a custom schedule used to exercise the type registry.
+type quarterHourSchedule struct {
+	Offset int `json:"offset"`
+}
+
+func (schedule quarterHourSchedule) NextRun(after time.Time) *time.Time {
+	nextRunTime := after.Truncate(15 * time.Minute).Add(time.Duration(schedule.Offset) * time.Minute)
+	for !nextRunTime.After(after) {
+		nextRunTime = nextRunTime.Add(15 * time.Minute)
+	}
+	return &nextRunTime
+}
+
+func (schedule quarterHourSchedule) Description() string {
+	return "Every quarter hour"
+}
+
+func (schedule quarterHourSchedule) Validate() error {
+	if schedule.Offset < 0 || schedule.Offset >= 15 {
+		return errors.New("offset must be between 0 and 14")
+	}
+	return nil
+}
+
+// unregisteredSchedule is a custom schedule that is never registered.
+type unregisteredSchedule struct {
+	quarterHourSchedule
+}
+
+// unregisteredWindow is a custom window, which has no serialized form.
+type unregisteredWindow struct{}
+
+func (window unregisteredWindow) Range(t time.Time) (time.Time, time.Time, bool) {
+	return t, t.Add(time.Hour), true
+}
+
+func (window unregisteredWindow) Description() string {
+	return "always"
+}
+
+func TestRegisterScheduleType(t *testing.T) {
+	if err := scheduler.RegisterScheduleType("quarter_hour", quarterHourSchedule{}); err != nil {
+		t.Fatalf("Failed to register custom schedule type: %v", err)
+	}
+	if err := scheduler.RegisterScheduleType("quarter_hour", quarterHourSchedule{}); !errors.Is(err, scheduler.ErrScheduleTypeExists) {
+		t.Errorf("Expected ErrScheduleTypeExists on duplicate registration, got %v", err)
+	}
+
+	data, err := scheduler.MarshalScheduleJSON(quarterHourSchedule{Offset: 5})
+	if err != nil || string(data) != `{"type":"quarter_hour","offset":5}` {
+		t.Fatalf("Unexpected encoding %s (error %v)", data, err)
+	}
+	decoded, err := scheduler.UnmarshalScheduleJSON(data)
+	if err != nil || decoded != (quarterHourSchedule{Offset: 5}) {
+		t.Errorf("Unexpected decoded schedule %#v (error %v)", decoded, err)
+	}
+	if _, err := scheduler.UnmarshalScheduleJSON([]byte(`{"type":"quarter_hour","offset":20}`)); !errors.Is(err, scheduler.ErrInvalidScheduleData) {
+		t.Errorf("Expected Validate to reject the offset, got %v", err)
+	}
+
+	unregistered := scheduler.AnyOf(unregisteredSchedule{})
+	if _, err := scheduler.MarshalScheduleJSON(unregistered); !errors.Is(err, scheduler.ErrUnknownScheduleType) {
+		t.Errorf("Expected ErrUnknownScheduleType for an unregistered type, got %v", err)
+	}
+	customWindow := scheduler.Within(scheduler.DailySchedule{Hour: 9, Minute: 0}, scheduler.Window(unregisteredWindow{}))
+	if _, err := scheduler.MarshalScheduleJSON(customWindow); !errors.Is(err, scheduler.ErrUnknownScheduleType) {
+		t.Errorf("Expected ErrUnknownScheduleType for a custom window, got %v", err)
+	}
+}
+
+func TestScheduleValueInConfiguration(t *testing.T) {
+	type jobConfiguration struct {
+		Name     string                  `json:"name"`
+		Schedule scheduler.ScheduleValue `json:"schedule"`
+	}
+
+	configuration := `{"name": "report", "schedule": {"type": "weekday", "weekdays": ["Monday"], "hour": 8, "minute": 0}}`
+	var job jobConfiguration
+	if err := json.Unmarshal([]byte(configuration), &job); err != nil {
+		t.Fatalf("Failed to unmarshal configuration: %v", err)
+	}
+	if _, ok := job.Schedule.Schedule.(scheduler.WeekdaySchedule); !ok {
+		t.Fatalf("Expected a WeekdaySchedule, got %T", job.Schedule.Schedule)
+	}
+
+	// yaml.v2 decodes mappings as map[interface{}]interface{}.
+	var yamlValue scheduler.ScheduleValue
+	err := yamlValue.UnmarshalYAML(func(target interface{}) error {
+		*(target.(*interface{})) = map[interface{}]interface{}{
+			"type":      "bounded",
+			"schedule":  map[interface{}]interface{}{"type": "daily", "hour": 9, "minute": 0},
+			"not_after": "2026-12-31T00:00:00Z",
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to unmarshal YAML value: %v", err)
+	}
+	if !strings.HasPrefix(yamlValue.Schedule.Description(), "Daily at 09:00") {
+		t.Errorf("Unexpected schedule %q", yamlValue.Schedule.Description())
+	}
+	encoded, err := yamlValue.MarshalYAML()
+	if err != nil {
+		t.Fatalf("Failed to marshal YAML value: %v", err)
+	}
+	if encodedMap, ok := encoded.(map[string]interface{}); !ok || encodedMap["type"] != "bounded" {
+		t.Errorf("Unexpected YAML value %#v", encoded)
+	}
+}
+
+func TestTaskInfoJSON(t *testing.T) {
+	original := scheduler.TaskInfo{ID: "cleanup", Description: "Remove stale files", Schedule: scheduler.DailySchedule{Hour: 3, Minute: 0}}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Failed to marshal task info: %v", err)
+	}
+	var decoded scheduler.TaskInfo
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal task info: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("Expected %#v, got %#v", original, decoded)
+	}
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestAnyOfSchedule(t *testing.T) {
+	schedule := scheduler.AnyOf(
+		scheduler.DailySchedule{Hour: 17, Minute: 0, Location: time.UTC},
+		scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+	)
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC), 3)
+	assertRunTimes(t, "any of", runTimes, []time.Time{
+		time.Date(2026, time.March, 4, 17, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 5, 17, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestAllOfSchedule(t *testing.T) {
+	schedule := scheduler.AllOf(
+		scheduler.MustCronSchedule("0 9 * * *"),
+		scheduler.MonthlySchedule{Days: []int{13}, Hour: 9, Minute: 0},
+		scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Friday}, Hour: 9, Minute: 0},
+	)
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 2)
+	assertRunTimes(t, "friday the 13th", runTimes, []time.Time{
+		time.Date(2026, time.February, 13, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 13, 9, 0, 0, 0, time.UTC),
+	})
+
+	disjoint := scheduler.AllOf(
+		scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		scheduler.DailySchedule{Hour: 10, Minute: 0, Location: time.UTC},
+	)
+	if nextRunTime := disjoint.NextRun(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)); nextRunTime != nil {
+		t.Errorf("Expected no run for disjoint schedules, got %v", *nextRunTime)
+	}
+}
+
+func TestWithinAndExceptSchedule(t *testing.T) {
+	everyQuarterHour := scheduler.IntervalSchedule{
+		Interval:  15 * time.Minute,
+		StartTime: time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+	}
+	businessHours := scheduler.Between(9*time.Hour, 17*time.Hour).
+		On(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).
+		In(time.UTC)
+	maintenance := scheduler.ScheduleWindow{Schedule: scheduler.MustCronSchedule("0 12 * * WED"), Duration: time.Hour}
+	schedule := scheduler.Except(scheduler.Within(everyQuarterHour, businessHours), maintenance)
+
+	testCases := []struct {
+		description string
+		after       time.Time
+		expected    time.Time
+	}{
+		{"inside the window", time.Date(2026, time.March, 3, 10, 5, 0, 0, time.UTC), time.Date(2026, time.March, 3, 10, 15, 0, 0, time.UTC)},
+		{"before opening", time.Date(2026, time.March, 3, 6, 0, 0, 0, time.UTC), time.Date(2026, time.March, 3, 9, 0, 0, 0, time.UTC)},
+		{"closing is exclusive", time.Date(2026, time.March, 6, 16, 50, 0, 0, time.UTC), time.Date(2026, time.March, 9, 9, 0, 0, 0, time.UTC)},
+		{"maintenance window", time.Date(2026, time.March, 4, 11, 50, 0, 0, time.UTC), time.Date(2026, time.March, 4, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		nextRunTime := schedule.NextRun(testCase.after)
+		if nextRunTime == nil || !nextRunTime.Equal(testCase.expected) {
+			t.Errorf("%s: expected %v, got %v", testCase.description, testCase.expected, nextRunTime)
+		}
+	}
+}
+
+func TestWithinOvernightWindow(t *testing.T) {
+	hourly := scheduler.MustCronSchedule("0 * * * *")
+	schedule := scheduler.Within(hourly, scheduler.Between(22*time.Hour, 6*time.Hour).In(time.UTC))
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.March, 4, 4, 30, 0, 0, time.UTC), 3)
+	assertRunTimes(t, "overnight", runTimes, []time.Time{
+		time.Date(2026, time.March, 4, 5, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 4, 22, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 4, 23, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestExceptDateRangeWindow(t *testing.T) {
+	freeze := scheduler.DateRangeWindow{
+		Start: time.Date(2026, time.December, 20, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2027, time.January, 4, 0, 0, 0, 0, time.UTC),
+	}
+	schedule := scheduler.Except(scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}, freeze)
+
+	nextRunTime := schedule.NextRun(time.Date(2026, time.December, 19, 12, 0, 0, 0, time.UTC))
+	expected := time.Date(2027, time.January, 4, 9, 0, 0, 0, time.UTC)
+	if nextRunTime == nil || !nextRunTime.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, nextRunTime)
+	}
+}
+
+func TestCompositeScheduleDescriptions(t *testing.T) {
+	testCases := []struct {
+		schedule scheduler.TimeSchedule
+		expected string
+	}{
+		{
+			scheduler.AnyOf(scheduler.DailySchedule{Hour: 9, Minute: 0}, scheduler.DailySchedule{Hour: 17, Minute: 0}),
+			"Any of: Daily at 09:00; Daily at 17:00",
+		},
+		{
+			scheduler.Within(
+				scheduler.IntervalSchedule{Interval: 15 * time.Minute},
+				scheduler.Between(9*time.Hour, 17*time.Hour+30*time.Minute).On(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday),
+			),
+			"Every 15m0s, between 09:00 and 17:30 on weekdays",
+		},
+		{
+			scheduler.Except(
+				scheduler.IntervalSchedule{Interval: time.Hour},
+				scheduler.ScheduleWindow{Schedule: scheduler.DailySchedule{Hour: 2, Minute: 0}, Duration: 2 * time.Hour},
+				scheduler.Between(12*time.Hour, 13*time.Hour).On(time.Saturday),
+			),
+			"Every 1h0m0s, except for 2h0m0s from Daily at 02:00 or between 12:00 and 13:00 on Saturday",
+		},
+	}
+
+	for _, testCase := range testCases {
+		if description := testCase.schedule.Description(); description != testCase.expected {
+			t.Errorf("Expected description %q, got %q", testCase.expected, description)
+		}
+	}
+}
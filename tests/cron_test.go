@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestCronScheduleNextRun(t *testing.T) {
+	referenceTime := time.Date(2026, time.March, 4, 10, 17, 30, 0, time.UTC) // Wednesday
+
+	testCases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC)},
+		{"30 10,18 * * *", time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)},
+		{"10 10,18 * * *", time.Date(2026, time.March, 4, 18, 10, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * JAN,JUL *", time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * FRI", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * 1", time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */2", time.Date(2026, time.August, 1, 0, 0, 0, 0, time.UTC)},
+		{"5-10/5 11 * * *", time.Date(2026, time.March, 4, 11, 5, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.March, 4, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		cronSchedule, err := scheduler.NewCronSchedule(testCase.expression)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", testCase.expression, err)
+		}
+		nextRunTime := cronSchedule.NextRun(referenceTime)
+		if nextRunTime == nil {
+			t.Errorf("Expected a next run for %q, got nil", testCase.expression)
+			continue
+		}
+		if !nextRunTime.Equal(testCase.expected) {
+			t.Errorf("Expression %q: expected next run %v, got %v", testCase.expression, testCase.expected, *nextRunTime)
+		}
+	}
+}
+
+func TestCronScheduleNextRunIsStrictlyAfter(t *testing.T) {
+	cronSchedule := scheduler.MustCronSchedule("0 9 * * *")
+	exactRunTime := time.Date(2026, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+	nextRunTime := cronSchedule.NextRun(exactRunTime)
+	expected := time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC)
+	if nextRunTime == nil || !nextRunTime.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, nextRunTime)
+	}
+}
+
+func TestCronScheduleInvalidExpressions(t *testing.T) {
+	invalidExpressions := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * FOO *",
+		"1,,2 * * * *",
+		"@reboot",
+	}
+
+	for _, expression := range invalidExpressions {
+		_, err := scheduler.NewCronSchedule(expression)
+		if !errors.Is(err, scheduler.ErrInvalidCronExpression) {
+			t.Errorf("Expected ErrInvalidCronExpression for %q, got: %v", expression, err)
+		}
+	}
+}
+
+func TestCronScheduleDescription(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   string
+	}{
+		{"*/15 * * * *", "Every 15 minutes"},
+		{"30 9 * * MON-FRI", "At 09:30, on Monday through Friday"},
+		{"0 9,17 * * *", "At 09:00 and 17:00, every day"},
+		{"0 * * * *", "At minute 0 past every hour"},
+		{"@monthly", "At 00:00, on day 1 of the month"},
+		{"0 8 1,15 * *", "At 08:00, on day 1 and 15 of the month"},
+		{"0 6 * JAN-MAR SAT", "At 06:00, on Saturday, in January through March"},
+		{"0 */2 * * *", "At minute 0, every 2 hours"},
+		{"0 0 */2 * 1", "At 00:00, every 2 days of the month, on Monday"},
+	}
+
+	for _, testCase := range testCases {
+		cronSchedule := scheduler.MustCronSchedule(testCase.expression)
+		if description := cronSchedule.Description(); description != testCase.expected {
+			t.Errorf("Expression %q: expected description %q, got %q", testCase.expression, testCase.expected, description)
+		}
+	}
+}
+
+func TestCronScheduleQuartzExtensions(t *testing.T) {
+	referenceTime := time.Date(2026, time.January, 10, 12, 0, 0, 0, time.UTC) // Saturday
+
+	testCases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"30 * * * * ?", time.Date(2026, time.January, 10, 12, 0, 30, 0, time.UTC)},
+		{"*/20 * * * * ?", time.Date(2026, time.January, 10, 12, 0, 20, 0, time.UTC)},
+		{"0 0 18 L * ?", time.Date(2026, time.January, 31, 18, 0, 0, 0, time.UTC)},
+		{"0 0 18 L-2 * ?", time.Date(2026, time.January, 29, 18, 0, 0, 0, time.UTC)},
+		{"0 0 18 LW * ?", time.Date(2026, time.January, 30, 18, 0, 0, 0, time.UTC)},
+		{"0 0 9 15W * ?", time.Date(2026, time.January, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 0 9 1W * ?", time.Date(2026, time.February, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 0 9 ? * 6#3", time.Date(2026, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 9 ? * FRI#3", time.Date(2026, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 9 ? * 6L", time.Date(2026, time.January, 30, 9, 0, 0, 0, time.UTC)},
+		{"0 0 9 ? * MON-FRI", time.Date(2026, time.January, 12, 9, 0, 0, 0, time.UTC)},
+		{"0 0 9 ? * 2-6", time.Date(2026, time.January, 12, 9, 0, 0, 0, time.UTC)},
+		{"0 0 0 1 1 ? 2028", time.Date(2028, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 0 L 2 ? 2028/4", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 9 * * 5L", time.Date(2026, time.January, 29, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		cronSchedule, err := scheduler.NewCronSchedule(testCase.expression)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", testCase.expression, err)
+		}
+		nextRunTime := cronSchedule.NextRun(referenceTime)
+		if nextRunTime == nil {
+			t.Errorf("Expected a next run for %q, got nil", testCase.expression)
+			continue
+		}
+		if !nextRunTime.Equal(testCase.expected) {
+			t.Errorf("Expression %q: expected next run %v, got %v", testCase.expression, testCase.expected, *nextRunTime)
+		}
+	}
+}
+
+func TestCronScheduleQuartzExhaustedYears(t *testing.T) {
+	cronSchedule := scheduler.MustCronSchedule("0 0 0 1 1 ? 2020")
+	if nextRunTime := cronSchedule.NextRun(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)); nextRunTime != nil {
+		t.Errorf("Expected no next run for a past year, got %v", *nextRunTime)
+	}
+}
+
+func TestCronScheduleQuartzInvalidExpressions(t *testing.T) {
+	invalidExpressions := []string{
+		"0 0 ? * * *",
+		"0 0 0 L-31 * ?",
+		"0 0 0 ? * 8",
+		"0 0 0 ? * 6#6",
+		"0 0 0 32W * ?",
+		"0 0 0 1 1 ? 1969",
+		"0 0 0 1 1 ? 2026 extra",
+	}
+
+	for _, expression := range invalidExpressions {
+		_, err := scheduler.NewCronSchedule(expression)
+		if !errors.Is(err, scheduler.ErrInvalidCronExpression) {
+			t.Errorf("Expected ErrInvalidCronExpression for %q, got: %v", expression, err)
+		}
+	}
+}
+
+func TestCronScheduleQuartzDescription(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   string
+	}{
+		{"*/10 * * * * ?", "Every 10 seconds"},
+		{"0 30 9 ? * MON-FRI", "At 09:30, on Monday through Friday"},
+		{"15 30 9 * * ?", "At 09:30:15, every day"},
+		{"0 0 18 LW * ?", "At 18:00, on the last weekday of the month"},
+		{"0 0 9 ? * FRI#3", "At 09:00, on the third Friday of the month"},
+		{"0 0 9 ? * 6L", "At 09:00, on the last Friday of the month"},
+		{"0 0 9 15W * ?", "At 09:00, on the weekday nearest day 15 of the month"},
+		{"0 0 0 L-2 * ? 2027", "At 00:00, on the last day of the month minus 2 days, in 2027"},
+	}
+
+	for _, testCase := range testCases {
+		cronSchedule := scheduler.MustCronSchedule(testCase.expression)
+		if description := cronSchedule.Description(); description != testCase.expected {
+			t.Errorf("Expression %q: expected description %q, got %q", testCase.expression, testCase.expected, description)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestSchedulerDispatchesManyTasks(t *testing.T) {
+	const taskCount = 2000
+	schedulerInstance := scheduler.NewScheduler()
+	testTasks := make([]*TestTask, taskCount)
+	dueTime := time.Now().Add(50 * time.Millisecond)
+	for index := range testTasks {
+		testTasks[index] = NewTestTask(fmt.Sprintf("dispatch-task-%d", index), scheduler.NewOneTimeSchedule(dueTime))
+		if err := schedulerInstance.RegisterTask(testTasks[index]); err != nil {
+			t.Fatalf("Failed to register task: %v", err)
+		}
+	}
+
+	schedulerInstance.Start()
+	deadline := time.Now().Add(5 * time.Second)
+	for countExecutions(testTasks) < taskCount && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	schedulerInstance.Stop()
+
+	for _, testTask := range testTasks {
+		if executionCount := testTask.GetExecutionCount(); executionCount != 1 {
+			t.Fatalf("Expected %s to run once, ran %d times", testTask.ID(), executionCount)
+		}
+	}
+}
+
+func TestSchedulerQueuesTasksRegisteredWhileRunning(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	// The dispatcher is idle with an empty queue until the task is registered.
+	testTask := NewTestTask("late-task", scheduler.NewOneTimeSchedule(time.Now().Add(50*time.Millisecond)))
+	if err := schedulerInstance.RegisterTask(testTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if !testTask.WaitForExecution(ctx) {
+		t.Fatalf("Task registered while running was never executed")
+	}
+}
+
+func TestSchedulerRestartsAfterStop(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+	testTask := NewTestTask("restarted-task", scheduler.IntervalSchedule{Interval: 20 * time.Millisecond})
+	if err := schedulerInstance.RegisterTask(testTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	schedulerInstance.Start()
+	schedulerInstance.Stop()
+	executionsAfterStop := testTask.GetExecutionCount()
+
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for testTask.GetExecutionCount() <= executionsAfterStop {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Task did not run again after the scheduler was restarted")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// countExecutions returns the total number of runs of the test tasks.
+func countExecutions(testTasks []*TestTask) int {
+	total := 0
+	for _, testTask := range testTasks {
+		total += int(testTask.GetExecutionCount())
+	}
+	return total
+}
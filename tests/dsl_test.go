@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestParseScheduleProducesExistingTypes(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+
+	testCases := []struct {
+		input    string
+		expected scheduler.TimeSchedule
+	}{
+		{"every 15m", scheduler.IntervalSchedule{Interval: 15 * time.Minute}},
+		{"Every 1h30m", scheduler.IntervalSchedule{Interval: 90 * time.Minute}},
+		{"daily at 09:30", scheduler.DailySchedule{Hour: 9, Minute: 30}},
+		{"daily at 9:30 Europe/Berlin", scheduler.DailySchedule{Hour: 9, Minute: 30, Location: berlin}},
+		{"daily at 09:30 in Europe/Berlin", scheduler.DailySchedule{Hour: 9, Minute: 30, Location: berlin}},
+		{"mon,wed,fri at 18:00", scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday, time.Wednesday, time.Friday}, Hour: 18}},
+		{"Monday, Friday at 07:15", scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday, time.Friday}, Hour: 7, Minute: 15}},
+		{"weekdays at 08:00", scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, Hour: 8}},
+		{"fri-mon at 23:00", scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Friday, time.Saturday, time.Sunday, time.Monday}, Hour: 23}},
+	}
+
+	for _, testCase := range testCases {
+		schedule, err := scheduler.ParseSchedule(testCase.input)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", testCase.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(schedule, testCase.expected) {
+			t.Errorf("Input %q: expected %#v, got %#v", testCase.input, testCase.expected, schedule)
+		}
+	}
+}
+
+func TestParseScheduleIntervalStartingAt(t *testing.T) {
+	schedule, err := scheduler.ParseSchedule("every 15m starting 10:00 UTC")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	intervalSchedule, ok := schedule.(scheduler.IntervalSchedule)
+	if !ok {
+		t.Fatalf("Expected an IntervalSchedule, got %T", schedule)
+	}
+	if expectedStart := time.Date(2000, time.January, 1, 10, 0, 0, 0, time.UTC); !intervalSchedule.StartTime.Equal(expectedStart) {
+		t.Errorf("Expected the start time %v, got %v", expectedStart, intervalSchedule.StartTime)
+	}
+
+	// The phase does not depend on the day the phrase is parsed.
+	nonDivisorSchedule, err := scheduler.ParseSchedule("every 7h starting 10:00 UTC")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expectedRun := time.Date(2026, time.March, 4, 19, 0, 0, 0, time.UTC)
+	if nextRunTime := nonDivisorSchedule.NextRun(time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)); nextRunTime == nil || !nextRunTime.Equal(expectedRun) {
+		t.Errorf("Expected %v, got %v", expectedRun, nextRunTime)
+	}
+
+	schedule, err = scheduler.ParseSchedule("every 1h starting 2026-11-01 06:30 Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expectedStart := time.Date(2026, time.November, 1, 6, 30, 0, 0, loadTestLocation(t, "Europe/Berlin"))
+	if startTime := schedule.(scheduler.IntervalSchedule).StartTime; !startTime.Equal(expectedStart) {
+		t.Errorf("Expected start %v, got %v", expectedStart, startTime)
+	}
+}
+
+func TestParseScheduleOnceAndCron(t *testing.T) {
+	schedule, err := scheduler.ParseSchedule("once at 2026-11-01T10:00Z")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	oneTimeSchedule, ok := schedule.(*scheduler.OneTimeSchedule)
+	if !ok {
+		t.Fatalf("Expected a *OneTimeSchedule, got %T", schedule)
+	}
+	expected := time.Date(2026, time.November, 1, 10, 0, 0, 0, time.UTC)
+	if nextRunTime := oneTimeSchedule.NextRun(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)); nextRunTime == nil || !nextRunTime.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, nextRunTime)
+	}
+
+	for _, input := range []string{"*/5 * * * *", "cron 0 9 * * MON-FRI", "@daily", "CRON_TZ=Europe/Berlin 0 9 * * *", "0 0 12 ? * WED"} {
+		schedule, err := scheduler.ParseSchedule(input)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", input, err)
+			continue
+		}
+		if _, ok := schedule.(scheduler.CronSchedule); !ok {
+			t.Errorf("Input %q: expected a CronSchedule, got %T", input, schedule)
+		}
+	}
+}
+
+func TestParseScheduleErrorPositions(t *testing.T) {
+	testCases := []struct {
+		input            string
+		expectedPosition int
+	}{
+		{"", 0},
+		{"hourly at 10:00", 0},
+		{"every 15x", 6},
+		{"every -5m", 6},
+		{"every", 5},
+		{"daily 09:30", 6},
+		{"daily at 25:00", 9},
+		{"daily at 09:30 Mars/Olympus", 15},
+		{"daily at 09:30 in Europe/Berlin now", 32},
+		{"mon,fry at 18:00", 4},
+		{"mon-fry at 18:00", 4},
+		{"once at tomorrow", 8},
+		{"*/5 * * 13 *", 8},
+		{"cron 0 0 ? * * *", 9},
+	}
+
+	for _, testCase := range testCases {
+		_, err := scheduler.ParseSchedule(testCase.input)
+		var syntaxError *scheduler.ScheduleSyntaxError
+		if !errors.As(err, &syntaxError) || !errors.Is(err, scheduler.ErrInvalidScheduleSyntax) {
+			t.Errorf("Input %q: expected a ScheduleSyntaxError, got %v", testCase.input, err)
+			continue
+		}
+		if syntaxError.Position != testCase.expectedPosition {
+			t.Errorf("Input %q: expected error at position %d, got %d (%v)", testCase.input, testCase.expectedPosition, syntaxError.Position, err)
+		}
+	}
+
+	_, err := scheduler.ParseSchedule("*/5 * * 13 *")
+	if !errors.Is(err, scheduler.ErrInvalidCronExpression) {
+		t.Errorf("Expected the cron error to be wrapped, got %v", err)
+	}
+}
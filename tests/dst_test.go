@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// collectRuns returns the first count run times of schedule after start.
+func collectRuns(schedule scheduler.TimeSchedule, start time.Time, count int) []time.Time {
+	var runTimes []time.Time
+	currentTime := start
+	for index := 0; index < count; index++ {
+		nextRunTime := schedule.NextRun(currentTime)
+		if nextRunTime == nil {
+			break
+		}
+		runTimes = append(runTimes, nextRunTime.UTC())
+		currentTime = *nextRunTime
+	}
+	return runTimes
+}
+
+func assertRunTimes(t *testing.T, description string, actual []time.Time, expected []time.Time) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Errorf("%s: expected %d runs %v, got %d runs %v", description, len(expected), expected, len(actual), actual)
+		return
+	}
+	for index := range expected {
+		if !actual[index].Equal(expected[index]) {
+			t.Errorf("%s: run %d expected %v, got %v", description, index, expected[index], actual[index])
+		}
+	}
+}
+
+func TestDSTSpringForwardGap(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+	// Clocks jump from 02:00 EST to 03:00 EDT on March 8, 2026.
+	start := time.Date(2026, time.March, 7, 12, 0, 0, 0, newYork)
+
+	testCases := []struct {
+		name     string
+		schedule scheduler.TimeSchedule
+		expected []time.Time
+	}{
+		{
+			name:     "daily run at next valid time",
+			schedule: scheduler.DailySchedule{Hour: 2, Minute: 30, Location: newYork},
+			expected: []time.Time{
+				time.Date(2026, time.March, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 9, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "daily skip",
+			schedule: scheduler.DailySchedule{Hour: 2, Minute: 30, Location: newYork,
+				DST: scheduler.DSTPolicy{Gap: scheduler.DSTGapSkip}},
+			expected: []time.Time{
+				time.Date(2026, time.March, 9, 6, 30, 0, 0, time.UTC),
+				time.Date(2026, time.March, 10, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "weekday run at next valid time",
+			schedule: scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Sunday}, Hour: 2, Minute: 15,
+				Location: newYork},
+			expected: []time.Time{
+				time.Date(2026, time.March, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 15, 6, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "cron every 20 minutes collapses the gap into one run",
+			schedule: mustCronIn(t, "*/20 2-3 8 3 *", newYork),
+			expected: []time.Time{
+				time.Date(2026, time.March, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 8, 7, 20, 0, 0, time.UTC),
+				time.Date(2026, time.March, 8, 7, 40, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		runTimes := collectRuns(testCase.schedule, start, len(testCase.expected))
+		assertRunTimes(t, testCase.name, runTimes, testCase.expected)
+	}
+}
+
+func TestDSTFallBackOverlap(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+	// Clocks return from 02:00 EDT to 01:00 EST on November 1, 2026.
+	start := time.Date(2026, time.October, 31, 12, 0, 0, 0, newYork)
+
+	testCases := []struct {
+		name     string
+		schedule scheduler.TimeSchedule
+		expected []time.Time
+	}{
+		{
+			name:     "daily run once",
+			schedule: scheduler.DailySchedule{Hour: 1, Minute: 30, Location: newYork},
+			expected: []time.Time{
+				time.Date(2026, time.November, 1, 5, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 2, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "daily run both",
+			schedule: scheduler.DailySchedule{Hour: 1, Minute: 30, Location: newYork,
+				DST: scheduler.DSTPolicy{Overlap: scheduler.DSTOverlapRunBoth}},
+			expected: []time.Time{
+				time.Date(2026, time.November, 1, 5, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 6, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 2, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "cron run both",
+			schedule: func() scheduler.TimeSchedule {
+				cronSchedule := mustCronIn(t, "45 1 * * *", newYork)
+				cronSchedule.DST = scheduler.DSTPolicy{Overlap: scheduler.DSTOverlapRunBoth}
+				return cronSchedule
+			}(),
+			expected: []time.Time{
+				time.Date(2026, time.November, 1, 5, 45, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 6, 45, 0, 0, time.UTC),
+				time.Date(2026, time.November, 2, 6, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "cron every 30 minutes run both",
+			schedule: func() scheduler.TimeSchedule {
+				cronSchedule := mustCronIn(t, "*/30 1-2 1 11 *", newYork)
+				cronSchedule.DST = scheduler.DSTPolicy{Overlap: scheduler.DSTOverlapRunBoth}
+				return cronSchedule
+			}(),
+			expected: []time.Time{
+				time.Date(2026, time.November, 1, 5, 0, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 5, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 6, 30, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 7, 0, 0, 0, time.UTC),
+				time.Date(2026, time.November, 1, 7, 30, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		runTimes := collectRuns(testCase.schedule, start, len(testCase.expected))
+		assertRunTimes(t, testCase.name, runTimes, testCase.expected)
+	}
+}
+
+func TestDSTQueryDuringSecondOccurrence(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+	dailySchedule := scheduler.DailySchedule{Hour: 1, Minute: 45, Location: newYork,
+		DST: scheduler.DSTPolicy{Overlap: scheduler.DSTOverlapRunBoth}}
+
+	// 01:10 EST, during the repeated hour: 01:45 EST is still ahead.
+	queryTime := time.Date(2026, time.November, 1, 6, 10, 0, 0, time.UTC)
+	expected := time.Date(2026, time.November, 1, 6, 45, 0, 0, time.UTC)
+	nextRunTime := dailySchedule.NextRun(queryTime)
+	if nextRunTime == nil || !nextRunTime.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, nextRunTime)
+	}
+}
+
+func mustCronIn(t *testing.T, expression string, location *time.Location) scheduler.CronSchedule {
+	t.Helper()
+	cronSchedule, err := scheduler.NewCronSchedule(expression)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", expression, err)
+	}
+	cronSchedule.Location = location
+	return cronSchedule
+}
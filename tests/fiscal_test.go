@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestISOWeekScheduleFirstMondayOfWeekOne(t *testing.T) {
+	schedule := scheduler.ISOWeekSchedule{Weeks: []int{1}, Weekdays: []time.Weekday{time.Monday}, Hour: 9, Location: time.UTC}
+	// ISO week 1 of 2026 starts in December 2025; that of 2027 starts on January 4.
+	start := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+	assertRunTimes(t, "Monday of ISO week 1", collectRuns(schedule, start, 3), []time.Time{
+		time.Date(2025, time.December, 29, 9, 0, 0, 0, time.UTC),
+		time.Date(2027, time.January, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2028, time.January, 3, 9, 0, 0, 0, time.UTC),
+	})
+	if description := schedule.Description(); description != "At 09:00 on Monday of ISO week 1 (UTC)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+}
+
+func TestISOWeekScheduleLastAndLongWeeks(t *testing.T) {
+	start := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	lastFriday := scheduler.ISOWeekSchedule{Weeks: []int{-1}, Weekdays: []time.Weekday{time.Friday}, Hour: 17}
+	// 2026 has 53 ISO weeks, the last of which ends in January 2027.
+	assertRunTimes(t, "Friday of the last ISO week", collectRuns(lastFriday, start, 2), []time.Time{
+		time.Date(2027, time.January, 1, 17, 0, 0, 0, time.UTC),
+		time.Date(2027, time.December, 31, 17, 0, 0, 0, time.UTC),
+	})
+
+	weekFiftyThree := scheduler.ISOWeekSchedule{Weeks: []int{53}, Weekdays: []time.Weekday{time.Monday}}
+	assertRunTimes(t, "Monday of ISO week 53", collectRuns(weekFiftyThree, start, 2), []time.Time{
+		time.Date(2026, time.December, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2032, time.December, 27, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestFiscalQuarterEndWithAprilStart(t *testing.T) {
+	schedule := scheduler.FiscalSchedule{Calendar: scheduler.FiscalCalendar{StartMonth: time.April}, Event: scheduler.FiscalQuarterEnd, Hour: 18, Location: time.UTC}
+	start := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	assertRunTimes(t, "fiscal quarter ends", collectRuns(schedule, start, 4), []time.Time{
+		time.Date(2026, time.March, 31, 18, 0, 0, 0, time.UTC),
+		time.Date(2026, time.June, 30, 18, 0, 0, 0, time.UTC),
+		time.Date(2026, time.September, 30, 18, 0, 0, 0, time.UTC),
+		time.Date(2026, time.December, 31, 18, 0, 0, 0, time.UTC),
+	})
+	if description := schedule.Description(); description != "At 18:00 on the last day of each fiscal quarter (fiscal year starting April) (UTC)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+	if note := schedule.ExplainNextRun(start); note != "Q4 FY2026" {
+		t.Errorf("Expected the run to close Q4 FY2026, got %q", note)
+	}
+	if note := schedule.ExplainNextRun(time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)); note != "Q1 FY2027" {
+		t.Errorf("Expected the run to close Q1 FY2027, got %q", note)
+	}
+}
+
+func TestFiscalFourFourFivePeriodClose(t *testing.T) {
+	// A retail calendar whose year ends on the last Saturday of January. FY2026 ends on
+	// January 31, 2026 and has 53 weeks, so its last period is six weeks long.
+	schedule := scheduler.FiscalSchedule{
+		Calendar: scheduler.FiscalCalendar{StartMonth: time.February, WeekPattern: []int{4, 4, 5}, YearEndWeekday: time.Saturday},
+		Event:    scheduler.FiscalPeriodEnd,
+		Hour:     20,
+	}
+	start := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+	assertRunTimes(t, "4-4-5 period closes", collectRuns(schedule, start, 5), []time.Time{
+		time.Date(2025, time.December, 20, 20, 0, 0, 0, time.UTC),
+		time.Date(2026, time.January, 31, 20, 0, 0, 0, time.UTC),
+		time.Date(2026, time.February, 28, 20, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 28, 20, 0, 0, 0, time.UTC),
+		time.Date(2026, time.May, 2, 20, 0, 0, 0, time.UTC),
+	})
+	if note := schedule.ExplainNextRun(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)); note != "P2 FY2027" {
+		t.Errorf("Expected the run to close P2 FY2027, got %q", note)
+	}
+	if description := schedule.Description(); description != "At 20:00 on the last day of each fiscal period (4-4-5 fiscal calendar ending on the last Saturday of January)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+
+	schedule.Calendar.YearEndNearest = true
+	schedule.Event = scheduler.FiscalYearEnd
+	// January 31, 2029 is a Wednesday, so the nearest Saturday falls in February.
+	assertRunTimes(t, "nearest-Saturday year ends", collectRuns(schedule, time.Date(2027, time.June, 1, 0, 0, 0, 0, time.UTC), 2), []time.Time{
+		time.Date(2028, time.January, 29, 20, 0, 0, 0, time.UTC),
+		time.Date(2029, time.February, 3, 20, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestFiscalAndISOWeekValidationAndCodec(t *testing.T) {
+	invalidSchedules := []scheduler.TimeSchedule{
+		scheduler.ISOWeekSchedule{Weeks: []int{54}, Weekdays: []time.Weekday{time.Monday}},
+		scheduler.ISOWeekSchedule{Weeks: []int{1}},
+		scheduler.FiscalSchedule{Calendar: scheduler.FiscalCalendar{StartMonth: 13}},
+		scheduler.FiscalSchedule{Calendar: scheduler.FiscalCalendar{WeekPattern: []int{4, 4, 4}}},
+	}
+	for _, schedule := range invalidSchedules {
+		if err := scheduler.ValidateSchedule(schedule); !errors.Is(err, scheduler.ErrInvalidSchedule) {
+			t.Errorf("Expected %#v to be rejected, got %v", schedule, err)
+		}
+	}
+
+	schedules := []scheduler.TimeSchedule{
+		scheduler.ISOWeekSchedule{Weeks: []int{1, -1}, Weekdays: []time.Weekday{time.Monday, time.Friday}, Hour: 9, Location: time.UTC},
+		scheduler.FiscalSchedule{
+			Calendar: scheduler.FiscalCalendar{StartMonth: time.July, WeekPattern: []int{5, 4, 4}, YearEndWeekday: time.Sunday, YearEndNearest: true},
+			Event:    scheduler.FiscalQuarterStart, Hour: 6, Minute: 30,
+		},
+	}
+	for _, schedule := range schedules {
+		data, err := scheduler.MarshalScheduleJSON(schedule)
+		if err != nil {
+			t.Fatalf("Failed to marshal %#v: %v", schedule, err)
+		}
+		decoded, err := scheduler.UnmarshalScheduleJSON(data)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", data, err)
+		}
+		if !reflect.DeepEqual(decoded, schedule) {
+			t.Errorf("Expected %s to round-trip, got %#v", data, decoded)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestAlignedIntervalSnapsToQuarterHours(t *testing.T) {
+	schedule := scheduler.IntervalSchedule{Interval: 15 * time.Minute, Aligned: true, Location: time.UTC}
+	start := time.Date(2026, time.March, 2, 10, 7, 13, 0, time.UTC)
+	assertRunTimes(t, "every 15m aligned", collectRuns(schedule, start, 4), []time.Time{
+		time.Date(2026, time.March, 2, 10, 15, 0, 0, time.UTC),
+		time.Date(2026, time.March, 2, 10, 30, 0, 0, time.UTC),
+		time.Date(2026, time.March, 2, 10, 45, 0, 0, time.UTC),
+		time.Date(2026, time.March, 2, 11, 0, 0, 0, time.UTC),
+	})
+
+	// A process restarted at a different moment lands on the same grid.
+	restarted := schedule.NextRun(time.Date(2026, time.March, 2, 10, 31, 59, 0, time.UTC))
+	if restarted == nil || !restarted.Equal(time.Date(2026, time.March, 2, 10, 45, 0, 0, time.UTC)) {
+		t.Errorf("Expected the restarted schedule to run at 10:45, got %v", restarted)
+	}
+}
+
+func TestAlignedIntervalRestartsAtMidnight(t *testing.T) {
+	schedule := scheduler.IntervalSchedule{Interval: 7 * time.Hour, Aligned: true, Location: time.UTC}
+	start := time.Date(2026, time.March, 2, 20, 0, 0, 0, time.UTC)
+	assertRunTimes(t, "every 7h aligned", collectRuns(schedule, start, 3), []time.Time{
+		time.Date(2026, time.March, 2, 21, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 3, 7, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestAlignedIntervalUsesLocation(t *testing.T) {
+	kolkata := loadTestLocation(t, "Asia/Kolkata")
+	schedule := scheduler.IntervalSchedule{Interval: time.Hour, Aligned: true, Location: kolkata}
+	start := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	nextRunTime := schedule.NextRun(start)
+	if nextRunTime == nil || !nextRunTime.Equal(time.Date(2026, time.March, 2, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the top of the hour in Kolkata at 10:30 UTC, got %v", nextRunTime)
+	}
+}
+
+func TestAlignedIntervalAcrossDaylightSavingTime(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+	schedule := scheduler.IntervalSchedule{Interval: 2 * time.Hour, Aligned: true, Location: newYork}
+	start := time.Date(2026, time.March, 8, 1, 30, 0, 0, newYork)
+	// 02:00 does not exist on the day clocks spring forward; the wall-clock grid still holds afterwards.
+	assertRunTimes(t, "every 2h aligned in New York", collectRuns(schedule, start, 3), []time.Time{
+		time.Date(2026, time.March, 8, 3, 0, 0, 0, newYork),
+		time.Date(2026, time.March, 8, 4, 0, 0, 0, newYork),
+		time.Date(2026, time.March, 8, 6, 0, 0, 0, newYork),
+	})
+}
+
+func TestAlignedIntervalLongerThanADay(t *testing.T) {
+	schedule := scheduler.IntervalSchedule{Interval: 48 * time.Hour, Aligned: true, Location: time.UTC}
+	start := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	// Counted from 1970-01-01, 2026-03-04 is an even number of days later.
+	assertRunTimes(t, "every 48h aligned", collectRuns(schedule, start, 2), []time.Time{
+		time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestAlignedIntervalStartTime(t *testing.T) {
+	startTime := time.Date(2026, time.March, 2, 10, 20, 0, 0, time.UTC)
+	schedule := scheduler.IntervalSchedule{Interval: 15 * time.Minute, Aligned: true, Location: time.UTC, StartTime: startTime}
+	nextRunTime := schedule.NextRun(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	if nextRunTime == nil || !nextRunTime.Equal(time.Date(2026, time.March, 2, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the first aligned run after the start time, got %v", nextRunTime)
+	}
+}
+
+func TestAlignedIntervalValidationAndParsing(t *testing.T) {
+	err := scheduler.ValidateSchedule(scheduler.IntervalSchedule{Interval: 1500 * time.Millisecond, Aligned: true})
+	if !errors.Is(err, scheduler.ErrInvalidSchedule) {
+		t.Errorf("Expected a fractional aligned interval to be rejected, got %v", err)
+	}
+
+	schedule, err := scheduler.ParseSchedule("every 15m aligned UTC")
+	if err != nil {
+		t.Fatalf("Failed to parse aligned interval: %v", err)
+	}
+	expected := scheduler.IntervalSchedule{Interval: 15 * time.Minute, Aligned: true, Location: time.UTC}
+	if !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, schedule)
+	}
+	if description := schedule.Description(); description != "Every 15m0s, aligned to the clock (UTC)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+
+	data, err := scheduler.MarshalScheduleJSON(schedule)
+	if err != nil {
+		t.Fatalf("Failed to marshal aligned interval: %v", err)
+	}
+	decoded, err := scheduler.UnmarshalScheduleJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("Expected %s to round-trip, got %#v", data, decoded)
+	}
+}
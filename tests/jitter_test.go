@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestJitterScheduleIsDeterministicAndBounded(t *testing.T) {
+	midnight := scheduler.DailySchedule{Hour: 0, Minute: 0, Location: time.UTC}
+	referenceTime := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	maxJitter := 30 * time.Minute
+
+	distinctRunTimes := make(map[time.Time]bool)
+	for _, replica := range []string{"replica-1", "replica-2", "replica-3", "replica-4", "replica-5"} {
+		firstSchedule := scheduler.WithJitter(midnight, maxJitter, "nightly@"+replica)
+		restartedSchedule := scheduler.WithJitter(midnight, maxJitter, "nightly@"+replica)
+
+		firstRunTime := firstSchedule.NextRun(referenceTime)
+		restartedRunTime := restartedSchedule.NextRun(referenceTime)
+		if firstRunTime == nil || restartedRunTime == nil || !firstRunTime.Equal(*restartedRunTime) {
+			t.Fatalf("Expected identical run times for the same seed, got %v and %v", firstRunTime, restartedRunTime)
+		}
+		baseRunTime := time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)
+		if firstRunTime.Before(baseRunTime) || !firstRunTime.Before(baseRunTime.Add(maxJitter)) {
+			t.Errorf("Run time %v is outside [%v, %v)", *firstRunTime, baseRunTime, baseRunTime.Add(maxJitter))
+		}
+		distinctRunTimes[*firstRunTime] = true
+	}
+	if len(distinctRunTimes) < 2 {
+		t.Errorf("Expected different seeds to spread runs, got %d distinct run times", len(distinctRunTimes))
+	}
+}
+
+func TestJitterScheduleRunsOncePerBaseRun(t *testing.T) {
+	schedule := scheduler.WithJitter(scheduler.DailySchedule{Hour: 0, Minute: 0, Location: time.UTC}, 2*time.Hour, "seed")
+	referenceTime := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	// A query made after midnight but before the delayed run still finds the delayed run.
+	delayedRunTime := schedule.NextRun(referenceTime)
+	if delayedRunTime == nil {
+		t.Fatal("Expected a run, got nil")
+	}
+	fromMidnight := schedule.NextRun(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC))
+	if fromMidnight == nil || (delayedRunTime.After(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)) && !fromMidnight.Equal(*delayedRunTime)) {
+		t.Errorf("Expected %v after midnight, got %v", *delayedRunTime, fromMidnight)
+	}
+
+	followingRunTime := schedule.NextRun(*delayedRunTime)
+	if followingRunTime == nil || followingRunTime.Sub(*delayedRunTime) < 22*time.Hour {
+		t.Errorf("Expected the following run on the next day, got %v after %v", followingRunTime, *delayedRunTime)
+	}
+}
+
+func TestRandomWindowSchedule(t *testing.T) {
+	window := scheduler.Between(1*time.Hour, 5*time.Hour).In(time.UTC)
+	schedule := scheduler.RandomTimeWithin(window, scheduler.HostSeed("backup"))
+
+	runTimes := collectRuns(schedule, time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC), 5)
+	if len(runTimes) != 5 {
+		t.Fatalf("Expected 5 runs, got %d", len(runTimes))
+	}
+	for index, runTime := range runTimes {
+		expectedDay := time.Date(2026, time.March, 5+index, 0, 0, 0, 0, time.UTC)
+		if runTime.Before(expectedDay.Add(time.Hour)) || !runTime.Before(expectedDay.Add(5*time.Hour)) {
+			t.Errorf("Run %d at %v is outside the 01:00-05:00 window of %s", index, runTime, expectedDay.Format("2006-01-02"))
+		}
+	}
+
+	repeatedRunTime := schedule.NextRun(time.Date(2026, time.March, 5, 1, 0, 0, 0, time.UTC))
+	if repeatedRunTime == nil || (runTimes[0].After(time.Date(2026, time.March, 5, 1, 0, 0, 0, time.UTC)) && !repeatedRunTime.Equal(runTimes[0])) {
+		t.Errorf("Expected a stable run time %v, got %v", runTimes[0], repeatedRunTime)
+	}
+}
+
+func TestJitterDescriptions(t *testing.T) {
+	jittered := scheduler.WithRandomJitter(scheduler.DailySchedule{Hour: 0, Minute: 0}, 15*time.Minute)
+	if description := jittered.Description(); description != "Daily at 00:00, delayed by up to 15m0s" {
+		t.Errorf("Unexpected description %q", description)
+	}
+	if jittered.Seed == "" || jittered.Seed == scheduler.RandomSeed() {
+		t.Errorf("Expected a fresh random seed, got %q", jittered.Seed)
+	}
+
+	random := scheduler.RandomTimeWithin(scheduler.Between(1*time.Hour, 5*time.Hour), "seed")
+	if description := random.Description(); !strings.HasPrefix(description, "At a random time between 01:00 and 05:00") {
+		t.Errorf("Unexpected description %q", description)
+	}
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func loadTestLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load time zone %q: %v", name, err)
+	}
+	return location
+}
+
+func TestScheduleLocationIndependentOfInputLocation(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+	tokyo := loadTestLocation(t, "Asia/Tokyo")
+
+	referenceInstant := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC) // 07:00 in New York, Monday
+	expected := time.Date(2026, time.March, 2, 9, 0, 0, 0, newYork)
+
+	schedules := []scheduler.TimeSchedule{
+		scheduler.DailySchedule{Hour: 9, Minute: 0, Location: newYork},
+		scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday}, Hour: 9, Minute: 0, Location: newYork},
+		scheduler.MustCronSchedule("CRON_TZ=America/New_York 0 9 * * MON"),
+	}
+
+	for _, schedule := range schedules {
+		for _, inputLocation := range []*time.Location{time.UTC, tokyo, newYork} {
+			nextRunTime := schedule.NextRun(referenceInstant.In(inputLocation))
+			if nextRunTime == nil || !nextRunTime.Equal(expected) {
+				t.Errorf("%s with input in %s: expected %v, got %v", schedule.Description(), inputLocation, expected, nextRunTime)
+				continue
+			}
+			if nextRunTime.Location().String() != newYork.String() {
+				t.Errorf("%s: expected next run in %s, got %s", schedule.Description(), newYork, nextRunTime.Location())
+			}
+		}
+	}
+}
+
+func TestSchedulerLocation(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	tokyo := loadTestLocation(t, "Asia/Tokyo")
+	startTime := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		options  []scheduler.Option
+		schedule scheduler.DailySchedule
+		expected time.Time
+	}{
+		{"scheduler location", []scheduler.Option{scheduler.WithLocation(berlin)}, scheduler.DailySchedule{Hour: 9, Minute: 30}, time.Date(2026, time.June, 2, 9, 30, 0, 0, berlin)},
+		{"clock location", nil, scheduler.DailySchedule{Hour: 9, Minute: 30}, time.Date(2026, time.June, 2, 9, 30, 0, 0, time.UTC)},
+		{"explicit location wins", []scheduler.Option{scheduler.WithLocation(berlin)}, scheduler.DailySchedule{Hour: 9, Minute: 30, Location: tokyo}, time.Date(2026, time.June, 2, 9, 30, 0, 0, tokyo)},
+	}
+	for _, testCase := range testCases {
+		fakeClock := scheduler.NewFakeClock(startTime)
+		schedulerInstance := scheduler.NewScheduler(append(testCase.options, scheduler.WithClock(fakeClock))...)
+		if err := schedulerInstance.RegisterTask(NewTestTask("located-task", testCase.schedule)); err != nil {
+			t.Fatalf("%s: failed to register task: %v", testCase.name, err)
+		}
+		schedulerInstance.Start()
+		waitForTimers(t, fakeClock, 1)
+		if pendingTimers := fakeClock.PendingTimers(); len(pendingTimers) != 1 || !pendingTimers[0].Equal(testCase.expected) {
+			t.Errorf("%s: expected the run at %v, got timers at %v", testCase.name, testCase.expected, pendingTimers)
+		}
+		schedulerInstance.Stop()
+	}
+
+	if description := (scheduler.DailySchedule{Hour: 9, Minute: 30}).Description(); description != "Daily at 09:30" {
+		t.Errorf("Expected no zone in the description of a schedule without a location, got %q", description)
+	}
+}
+
+func TestScheduleDescriptionIncludesLocation(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+
+	descriptions := []string{
+		scheduler.DailySchedule{Hour: 9, Minute: 0, Location: newYork}.Description(),
+		scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday}, Hour: 9, Minute: 0, Location: newYork}.Description(),
+		scheduler.MustCronSchedule("TZ=America/New_York 0 9 * * *").Description(),
+	}
+	for _, description := range descriptions {
+		if !strings.HasSuffix(description, "(America/New_York)") {
+			t.Errorf("Expected description to name the time zone, got %q", description)
+		}
+	}
+
+	if description := (scheduler.DailySchedule{Hour: 9, Minute: 0}).Description(); description != "Daily at 09:00" {
+		t.Errorf("Expected no time zone without a location, got %q", description)
+	}
+}
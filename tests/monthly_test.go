@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestMonthlyScheduleNextRuns(t *testing.T) {
+	start := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		schedule scheduler.MonthlySchedule
+		expected []time.Time
+	}{
+		{
+			name:     "first of the month",
+			schedule: scheduler.MonthlySchedule{Days: []int{1}, Hour: 9, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "last day of the month",
+			schedule: scheduler.MonthlySchedule{Days: []int{-1}, Hour: 18, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.January, 31, 18, 0, 0, 0, time.UTC),
+				time.Date(2026, time.February, 28, 18, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 31, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "third to last day",
+			schedule: scheduler.MonthlySchedule{Days: []int{-3}, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.January, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.February, 26, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "day 31 skips short months",
+			schedule: scheduler.MonthlySchedule{Days: []int{31}, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.May, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "day 31 clamps in short months",
+			schedule: scheduler.MonthlySchedule{Days: []int{31}, ShortMonths: scheduler.ShortMonthClamp, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "second Tuesday",
+			schedule: scheduler.MonthlySchedule{NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Tuesday, N: 2}},
+				Hour: 10, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.February, 10, 10, 0, 0, 0, time.UTC),
+				time.Date(2026, time.March, 10, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last Friday",
+			schedule: scheduler.MonthlySchedule{NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Friday, N: -1}},
+				Hour: 16, Minute: 30, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.January, 30, 16, 30, 0, 0, time.UTC),
+				time.Date(2026, time.February, 27, 16, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "fifth Monday only in months that have one",
+			schedule: scheduler.MonthlySchedule{NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Monday, N: 5}},
+				Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.March, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, time.June, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "days and weekdays combined",
+			schedule: scheduler.MonthlySchedule{Days: []int{1, 15},
+				NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Monday, N: 1}}, Hour: 8, Location: time.UTC},
+			expected: []time.Time{
+				time.Date(2026, time.February, 1, 8, 0, 0, 0, time.UTC),
+				time.Date(2026, time.February, 2, 8, 0, 0, 0, time.UTC),
+				time.Date(2026, time.February, 15, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		runTimes := collectRuns(testCase.schedule, start, len(testCase.expected))
+		assertRunTimes(t, testCase.name, runTimes, testCase.expected)
+	}
+}
+
+func TestMonthlyScheduleDescription(t *testing.T) {
+	testCases := []struct {
+		schedule scheduler.MonthlySchedule
+		expected string
+	}{
+		{scheduler.MonthlySchedule{Days: []int{1}, Hour: 9}, "Monthly on the 1st at 09:00"},
+		{scheduler.MonthlySchedule{Days: []int{1, -1}, Hour: 9}, "Monthly on the 1st and the last day at 09:00"},
+		{scheduler.MonthlySchedule{Days: []int{-2}, Hour: 9}, "Monthly on the 2nd to last day at 09:00"},
+		{scheduler.MonthlySchedule{Days: []int{31}, ShortMonths: scheduler.ShortMonthClamp, Hour: 9},
+			"Monthly on the 31st (clamped in shorter months) at 09:00"},
+		{scheduler.MonthlySchedule{NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Tuesday, N: 2}}, Hour: 10, Minute: 30},
+			"Monthly on the 2nd Tuesday at 10:30"},
+		{scheduler.MonthlySchedule{NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Friday, N: -1}}, Hour: 16},
+			"Monthly on the last Friday at 16:00"},
+	}
+
+	for _, testCase := range testCases {
+		if description := testCase.schedule.Description(); description != testCase.expected {
+			t.Errorf("Expected description %q, got %q", testCase.expected, description)
+		}
+	}
+}
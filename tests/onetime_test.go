@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestOneTimeScheduleNextRunIsPure(testContext *testing.T) {
+	runTime := time.Date(2026, time.November, 1, 10, 0, 0, 0, time.UTC)
+	schedule := scheduler.NewOneTimeSchedule(runTime)
+
+	for attempt := 0; attempt < 3; attempt++ {
+		if nextRunTime := schedule.NextRun(runTime.Add(time.Minute)); nextRunTime != nil {
+			testContext.Fatalf("Expected no run after the run time, got %v", nextRunTime)
+		}
+		if nextRunTime := schedule.NextRun(runTime); nextRunTime != nil {
+			testContext.Fatalf("Expected no run at the run time itself, got %v", nextRunTime)
+		}
+	}
+	previewedRuns := scheduler.Preview(schedule, runTime.Add(-time.Hour), 5)
+	if len(previewedRuns) != 1 || !previewedRuns[0].Equal(runTime) {
+		testContext.Fatalf("Expected a single previewed run at %v, got %v", runTime, previewedRuns)
+	}
+	if nextRunTime := schedule.NextRun(runTime.Add(-time.Hour)); nextRunTime == nil || !nextRunTime.Equal(runTime) {
+		testContext.Errorf("Expected previewing not to consume the run, got %v", nextRunTime)
+	}
+}
+
+func TestSchedulerRunsOverdueOneShotOnce(testContext *testing.T) {
+	completionPath := filepath.Join(testContext.TempDir(), "completions.json")
+	store, err := scheduler.NewFileCompletionStore(completionPath)
+	if err != nil {
+		testContext.Fatalf("Failed to open completion store: %v", err)
+	}
+	overdueSchedule := scheduler.NewOneTimeSchedule(time.Now().Add(-time.Hour))
+	firstTask := NewTestTask("overdue-one-shot", overdueSchedule)
+
+	firstScheduler := scheduler.NewScheduler()
+	firstScheduler.SetCompletionStore(store)
+	if err := firstScheduler.RegisterTask(firstTask); err != nil {
+		testContext.Fatalf("Failed to register task: %v", err)
+	}
+	firstScheduler.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if !overdueSchedule.WaitForExecution(ctx) {
+		testContext.Fatalf("Expected the overdue one-shot task to run")
+	}
+	firstScheduler.Stop()
+
+	// A restarted scheduler reading the same file must not run it again.
+	reopenedStore, err := scheduler.NewFileCompletionStore(completionPath)
+	if err != nil {
+		testContext.Fatalf("Failed to reopen completion store: %v", err)
+	}
+	secondTask := NewTestTask("overdue-one-shot", overdueSchedule)
+	secondScheduler := scheduler.NewScheduler()
+	secondScheduler.SetCompletionStore(reopenedStore)
+	if err := secondScheduler.RegisterTask(secondTask); err != nil {
+		testContext.Fatalf("Failed to register task: %v", err)
+	}
+	secondScheduler.Start()
+	time.Sleep(100 * time.Millisecond)
+	secondScheduler.Stop()
+
+	if executionCount := firstTask.GetExecutionCount(); executionCount != 1 {
+		testContext.Errorf("Expected the first scheduler to run the task once, got %d", executionCount)
+	}
+	if executionCount := secondTask.GetExecutionCount(); executionCount != 0 {
+		testContext.Errorf("Expected the restarted scheduler not to run the completed task, got %d", executionCount)
+	}
+}
+
+func TestFileCompletionStoreKeepsFailedWriteOutOfMemory(testContext *testing.T) {
+	completionDirectory := filepath.Join(testContext.TempDir(), "state")
+	if err := os.Mkdir(completionDirectory, 0o755); err != nil {
+		testContext.Fatalf("Failed to create directory: %v", err)
+	}
+	store, err := scheduler.NewFileCompletionStore(filepath.Join(completionDirectory, "completions.json"))
+	if err != nil {
+		testContext.Fatalf("Failed to open completion store: %v", err)
+	}
+	if err := os.Remove(completionDirectory); err != nil {
+		testContext.Fatalf("Failed to remove directory: %v", err)
+	}
+
+	scheduledAt := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	if err := store.MarkCompleted("unwritable-task", scheduledAt); err == nil {
+		testContext.Fatal("Expected recording the completion to fail")
+	}
+	if completed, _ := store.IsCompleted("unwritable-task", scheduledAt); completed {
+		testContext.Error("Expected a completion that was not written not to be reported as completed")
+	}
+}
+
+// observedSchedule runs once shortly after creation and records the runs it is notified about.
+type observedSchedule struct {
+	runTime       time.Time
+	mutex         sync.Mutex
+	notifiedTimes []time.Time
+	notified      chan struct{}
+}
+
+func (schedule *observedSchedule) NextRun(afterTime time.Time) *time.Time {
+	if !schedule.runTime.After(afterTime) {
+		return nil
+	}
+	return &schedule.runTime
+}
+
+func (schedule *observedSchedule) Description() string {
+	return "observed"
+}
+
+func (schedule *observedSchedule) AfterExecution(scheduledAt time.Time, err error) {
+	schedule.mutex.Lock()
+	schedule.notifiedTimes = append(schedule.notifiedTimes, scheduledAt)
+	schedule.mutex.Unlock()
+	close(schedule.notified)
+}
+
+func TestSchedulerNotifiesExecutionObserver(testContext *testing.T) {
+	schedule := &observedSchedule{runTime: time.Now().Add(50 * time.Millisecond), notified: make(chan struct{})}
+	schedulerInstance := scheduler.NewScheduler()
+	if err := schedulerInstance.RegisterTask(NewTestTask("observed-task", schedule)); err != nil {
+		testContext.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	select {
+	case <-schedule.notified:
+	case <-time.After(2 * time.Second):
+		testContext.Fatalf("Expected the schedule to be notified after execution")
+	}
+	schedule.mutex.Lock()
+	defer schedule.mutex.Unlock()
+	if len(schedule.notifiedTimes) != 1 || !schedule.notifiedTimes[0].Equal(schedule.runTime) {
+		testContext.Errorf("Expected one notification for %v, got %v", schedule.runTime, schedule.notifiedTimes)
+	}
+}
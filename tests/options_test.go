@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// blockingTask runs until its context is done or it is released.
+type blockingTask struct {
+	*TestTask
+	running *atomic.Int32
+	release chan struct{}
+}
+
+func (task blockingTask) Run(ctx context.Context) error {
+	task.TestTask.Run(ctx)
+	task.running.Add(1)
+	defer task.running.Add(-1)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-task.release:
+		return nil
+	}
+}
+
+func TestSchedulerWithLogger(t *testing.T) {
+	var logOutput bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logOutput, nil))
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithLogger(logger))
+	testTask := NewTestTask("logged-task", scheduler.DailySchedule{Hour: 3})
+	if err := schedulerInstance.RegisterTask(testTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	if err := schedulerInstance.RunTaskNow(testTask.ID()); err != nil {
+		t.Fatalf("Failed to run task: %v", err)
+	}
+
+	if output := logOutput.String(); !strings.Contains(output, `msg="Executing task" task_id=logged-task on_demand=true`) {
+		t.Errorf("Expected the run to be logged to the configured logger, got:\n%s", output)
+	}
+}
+
+func TestSchedulerTimeoutOptions(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithRunTimeout(10 * time.Millisecond))
+	stuckTask := blockingTask{TestTask: NewTestTask("stuck-task", scheduler.DailySchedule{Hour: 3}), running: &atomic.Int32{}}
+	if err := schedulerInstance.RegisterTask(stuckTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	if err := schedulerInstance.RunTaskNow(stuckTask.ID()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the run to time out, got %v", err)
+	}
+}
+
+func TestSchedulerWithMaxConcurrency(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock), scheduler.WithMaxConcurrency(1))
+	running := &atomic.Int32{}
+	release := make(chan struct{})
+	var tasks []blockingTask
+	for _, taskID := range []string{"first-limited-task", "second-limited-task"} {
+		task := blockingTask{TestTask: NewTestTask(taskID, scheduler.NewOneTimeSchedule(fakeClock.Now())), running: running, release: release}
+		if err := schedulerInstance.RegisterTask(task); err != nil {
+			t.Fatalf("Failed to register task: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	waitForCondition(t, "the first run", func() bool { return running.Load() == 1 })
+	time.Sleep(20 * time.Millisecond)
+	if runningCount := running.Load(); runningCount != 1 {
+		t.Fatalf("Expected one run at a time, got %d", runningCount)
+	}
+	release <- struct{}{}
+	waitForCondition(t, "the second run", func() bool { return tasks[0].GetExecutionCount()+tasks[1].GetExecutionCount() == 2 })
+	release <- struct{}{}
+}
+
+func TestSchedulerErrorHandlerAndHooks(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	var events []string
+	var failedEvent scheduler.RunEvent
+	schedulerInstance := scheduler.NewScheduler(
+		scheduler.WithClock(fakeClock),
+		scheduler.WithHooks(scheduler.Hooks{
+			BeforeRun: func(event scheduler.RunEvent) { events = append(events, "before "+event.TaskID) },
+			AfterRun:  func(event scheduler.RunEvent) { events = append(events, "after "+event.TaskID) },
+		}),
+		scheduler.WithErrorHandler(func(event scheduler.RunEvent) { failedEvent = event }),
+	)
+	testTask := failingTask{NewTestTask("hooked-task", scheduler.DailySchedule{Hour: 3})}
+	if err := schedulerInstance.RegisterTask(testTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	runResult := make(chan error, 1)
+	go func() {
+		runResult <- schedulerInstance.RunTaskNow(testTask.ID())
+	}()
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(100 * time.Millisecond)
+	runError := <-runResult
+
+	if strings.Join(events, ", ") != "before hooked-task, after hooked-task" {
+		t.Errorf("Unexpected hook calls %v", events)
+	}
+	if failedEvent.TaskID != "hooked-task" || !failedEvent.OnDemand || failedEvent.Attempts != 2 || failedEvent.Err != runError {
+		t.Errorf("Unexpected failed run event %+v", failedEvent)
+	}
+	if !failedEvent.FinishedAt.Equal(failedEvent.StartedAt.Add(100 * time.Millisecond)) {
+		t.Errorf("Expected the run to take 100ms on the fake clock, got %v to %v", failedEvent.StartedAt, failedEvent.FinishedAt)
+	}
+}
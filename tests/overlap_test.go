@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// overlapTask is a blocking task with its own overlap policy.
+type overlapTask struct {
+	blockingTask
+	policy scheduler.OverlapPolicy
+}
+
+func (task overlapTask) OverlapPolicy() scheduler.OverlapPolicy {
+	return task.policy
+}
+
+// newOverlapTask registers a blocking task with the given overlap policy.
+func newOverlapTask(t *testing.T, schedulerInstance *scheduler.Scheduler, taskID string, schedule scheduler.TimeSchedule, policy scheduler.OverlapPolicy) overlapTask {
+	task := overlapTask{blockingTask: blockingTask{TestTask: NewTestTask(taskID, schedule), running: &atomic.Int32{}, release: make(chan struct{})}, policy: policy}
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	return task
+}
+
+// runInBackground calls RunTaskNow on a new goroutine and returns the channel its error is sent to.
+func runInBackground(schedulerInstance *scheduler.Scheduler, taskID string) <-chan error {
+	runResult := make(chan error, 1)
+	go func() {
+		runResult <- schedulerInstance.RunTaskNow(taskID)
+	}()
+	return runResult
+}
+
+// skipRecorder collects the runs skipped by overlap policies.
+type skipRecorder struct {
+	mutex  sync.Mutex
+	events []scheduler.RunEvent
+}
+
+func (recorder *skipRecorder) hooks() scheduler.Hooks {
+	return scheduler.Hooks{SkippedRun: func(event scheduler.RunEvent) {
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+		recorder.events = append(recorder.events, event)
+	}}
+}
+
+func (recorder *skipRecorder) skipped() []scheduler.RunEvent {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]scheduler.RunEvent(nil), recorder.events...)
+}
+
+func TestOverlapForbidSkipsRunTaskNow(t *testing.T) {
+	recorder := &skipRecorder{}
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithHooks(recorder.hooks()))
+	task := newOverlapTask(t, schedulerInstance, "forbidding-task", scheduler.DailySchedule{Hour: 3}, scheduler.OverlapPolicy{Mode: scheduler.OverlapForbid})
+
+	firstRun := runInBackground(schedulerInstance, task.ID())
+	waitForCondition(t, "the first run", func() bool { return task.running.Load() == 1 })
+	if err := schedulerInstance.RunTaskNow(task.ID()); !errors.Is(err, scheduler.ErrRunSkipped) {
+		t.Errorf("Expected the overlapping run to be skipped, got %v", err)
+	}
+	close(task.release)
+	if err := <-firstRun; err != nil {
+		t.Errorf("Expected the first run to succeed, got %v", err)
+	}
+
+	skipped := recorder.skipped()
+	if len(skipped) != 1 || skipped[0].TaskID != "forbidding-task" || !skipped[0].OnDemand || !errors.Is(skipped[0].Err, scheduler.ErrRunSkipped) {
+		t.Errorf("Expected one skipped run event, got %+v", skipped)
+	}
+	if executions := task.GetExecutionCount(); executions != 1 {
+		t.Errorf("Expected one execution, got %d", executions)
+	}
+}
+
+func TestOverlapQueueRunsOneAfterAnother(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+	task := newOverlapTask(t, schedulerInstance, "queueing-task", scheduler.DailySchedule{Hour: 3}, scheduler.OverlapPolicy{})
+
+	firstRun := runInBackground(schedulerInstance, task.ID())
+	waitForCondition(t, "the first run", func() bool { return task.running.Load() == 1 })
+	secondRun := runInBackground(schedulerInstance, task.ID())
+	thirdRun := runInBackground(schedulerInstance, task.ID())
+
+	// Only one of the overlapping runs waits; the other one is skipped.
+	var queuedRun <-chan error
+	select {
+	case err := <-secondRun:
+		queuedRun = thirdRun
+		if !errors.Is(err, scheduler.ErrRunSkipped) {
+			t.Fatalf("Expected the run to be skipped, got %v", err)
+		}
+	case err := <-thirdRun:
+		queuedRun = secondRun
+		if !errors.Is(err, scheduler.ErrRunSkipped) {
+			t.Fatalf("Expected the run to be skipped, got %v", err)
+		}
+	}
+	if running := task.running.Load(); running != 1 {
+		t.Fatalf("Expected the queued run to wait, got %d runs in progress", running)
+	}
+
+	task.release <- struct{}{}
+	if err := <-firstRun; err != nil {
+		t.Errorf("Expected the first run to succeed, got %v", err)
+	}
+	task.release <- struct{}{}
+	if err := <-queuedRun; err != nil {
+		t.Errorf("Expected the queued run to succeed, got %v", err)
+	}
+	if executions := task.GetExecutionCount(); executions != 2 {
+		t.Errorf("Expected two executions, got %d", executions)
+	}
+}
+
+func TestOverlapReplaceCancelsRunningRun(t *testing.T) {
+	var failedRuns atomic.Int32
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithErrorHandler(func(scheduler.RunEvent) { failedRuns.Add(1) }))
+	task := newOverlapTask(t, schedulerInstance, "replacing-task", scheduler.DailySchedule{Hour: 3}, scheduler.OverlapPolicy{Mode: scheduler.OverlapReplace})
+
+	firstRun := runInBackground(schedulerInstance, task.ID())
+	waitForCondition(t, "the first run", func() bool { return task.running.Load() == 1 })
+	replacingRun := runInBackground(schedulerInstance, task.ID())
+
+	if err := <-firstRun; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the first run to be canceled, got %v", err)
+	}
+	waitForCondition(t, "the replacing run", func() bool { return task.GetExecutionCount() == 2 })
+	close(task.release)
+	if err := <-replacingRun; err != nil {
+		t.Errorf("Expected the replacing run to succeed, got %v", err)
+	}
+	if failed := failedRuns.Load(); failed != 0 {
+		t.Errorf("Expected the replaced run not to be retried or reported as failed, got %d failures", failed)
+	}
+}
+
+func TestOverlapAllowLimitsParallelRuns(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+	task := newOverlapTask(t, schedulerInstance, "parallel-task", scheduler.DailySchedule{Hour: 3}, scheduler.OverlapPolicy{Mode: scheduler.OverlapAllow, MaxParallel: 2})
+
+	firstRun := runInBackground(schedulerInstance, task.ID())
+	secondRun := runInBackground(schedulerInstance, task.ID())
+	waitForCondition(t, "two parallel runs", func() bool { return task.running.Load() == 2 })
+	if err := schedulerInstance.RunTaskNow(task.ID()); !errors.Is(err, scheduler.ErrRunSkipped) {
+		t.Errorf("Expected a third run to be skipped, got %v", err)
+	}
+
+	close(task.release)
+	for _, runResult := range []<-chan error{firstRun, secondRun} {
+		if err := <-runResult; err != nil {
+			t.Errorf("Expected the parallel runs to succeed, got %v", err)
+		}
+	}
+}
+
+func TestOverlapPolicyAppliesToScheduledRuns(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	recorder := &skipRecorder{}
+	schedulerInstance := scheduler.NewScheduler(
+		scheduler.WithClock(fakeClock),
+		scheduler.WithHooks(recorder.hooks()),
+		scheduler.WithOverlapPolicy(scheduler.OverlapPolicy{Mode: scheduler.OverlapForbid}),
+	)
+	task := blockingTask{TestTask: NewTestTask("overrunning-task", scheduler.IntervalSchedule{Interval: time.Minute}), running: &atomic.Int32{}, release: make(chan struct{})}
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(time.Minute)
+	waitForCondition(t, "the first run", func() bool { return task.running.Load() == 1 })
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(time.Minute)
+	waitForCondition(t, "the overlapping run to be skipped", func() bool { return len(recorder.skipped()) == 1 })
+
+	if skipped := recorder.skipped()[0]; skipped.OnDemand || !skipped.ScheduledAt.Equal(fakeClock.Now()) {
+		t.Errorf("Expected the scheduled run due now to be skipped, got %+v", skipped)
+	}
+	if executions := task.GetExecutionCount(); executions != 1 {
+		t.Errorf("Expected one execution, got %d", executions)
+	}
+	close(task.release)
+}
+
+func TestOverlapReplaceOfOneTimeRunDoesNotRepeatIt(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock))
+	completionStore := scheduler.NewMemoryCompletionStore()
+	schedulerInstance.SetCompletionStore(completionStore)
+	oneTimeSchedule := scheduler.NewOneTimeSchedule(fakeClock.Now())
+	task := newOverlapTask(t, schedulerInstance, "replaced-one-time-task", oneTimeSchedule, scheduler.OverlapPolicy{Mode: scheduler.OverlapReplace})
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	waitForCondition(t, "the scheduled run", func() bool { return task.running.Load() == 1 })
+	onDemandRun := runInBackground(schedulerInstance, task.ID())
+	waitForCondition(t, "the on-demand run to replace it", func() bool {
+		return task.GetExecutionCount() == 2 && task.running.Load() == 1
+	})
+	close(task.release)
+	if err := <-onDemandRun; err != nil {
+		t.Fatalf("Expected the on-demand run to finish, got %v", err)
+	}
+
+	if completed, _ := completionStore.IsCompleted(task.ID(), oneTimeSchedule.RunTime()); !completed {
+		t.Error("Expected the replaced one-time run to be recorded")
+	}
+	fakeClock.Advance(time.Hour)
+	time.Sleep(20 * time.Millisecond)
+	if executions := task.GetExecutionCount(); executions != 2 {
+		t.Errorf("Expected the one-time run not to run again, got %d executions", executions)
+	}
+}
+
+func TestOverlapQueueDoesNotHoldAWorker(t *testing.T) {
+	startTime := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	fakeClock := scheduler.NewFakeClock(startTime)
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock), scheduler.WithMaxConcurrency(2))
+	slowTask := newOverlapTask(t, schedulerInstance, "slow-queued-task", scheduler.IntervalSchedule{Interval: time.Minute}, scheduler.OverlapPolicy{Mode: scheduler.OverlapQueue})
+	otherTask := NewTestTask("unrelated-task", scheduler.NewOneTimeSchedule(startTime.Add(3*time.Minute)))
+	if err := schedulerInstance.RegisterTask(otherTask); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(time.Minute)
+	waitForCondition(t, "the slow run", func() bool { return slowTask.running.Load() == 1 })
+	// The next run falls due while the first is in progress and waits for it.
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(time.Minute)
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(time.Minute)
+
+	waitForCondition(t, "the unrelated task to get the second worker", func() bool { return otherTask.GetExecutionCount() == 1 })
+	slowTask.release <- struct{}{}
+	waitForCondition(t, "the queued run", func() bool { return slowTask.GetExecutionCount() == 2 && slowTask.running.Load() == 1 })
+	close(slowTask.release)
+}
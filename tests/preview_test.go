@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// stuckSchedule always returns the time it is given, which would loop forever without protection.
+type stuckSchedule struct{}
+
+func (schedule stuckSchedule) NextRun(after time.Time) *time.Time {
+	return &after
+}
+
+func (schedule stuckSchedule) Description() string {
+	return "Stuck"
+}
+
+func TestPreview(t *testing.T) {
+	from := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	runTimes := scheduler.Preview(scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}, from, 3)
+	assertRunTimes(t, "daily preview", runTimes, []time.Time{
+		time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 6, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 7, 9, 0, 0, 0, time.UTC),
+	})
+
+	bounded := scheduler.BoundedSchedule{
+		Schedule: scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC},
+		NotAfter: time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC),
+	}
+	if runTimes := scheduler.Preview(bounded, from, 10); len(runTimes) != 1 {
+		t.Errorf("Expected the preview to stop when the schedule ends, got %v", runTimes)
+	}
+
+	if runTimes := scheduler.Preview(stuckSchedule{}, from, 10); len(runTimes) != 0 {
+		t.Errorf("Expected no runs from a schedule that does not advance, got %v", runTimes)
+	}
+
+	if runTimes := scheduler.Preview(scheduler.DailySchedule{Hour: 9, Minute: 0}, from, 0); len(runTimes) != 0 {
+		t.Errorf("Expected no runs for n = 0, got %v", runTimes)
+	}
+}
+
+func TestPreviewBetween(t *testing.T) {
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.March, 3, 9, 0, 0, 0, time.UTC)
+	runTimes := scheduler.PreviewBetween(scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}, from, until, 10)
+	assertRunTimes(t, "bounded preview", runTimes, []time.Time{
+		time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 3, 9, 0, 0, 0, time.UTC),
+	})
+}
+
+// runCLI runs the CLI with the given arguments and clock and returns what it printed.
+func runCLI(t *testing.T, clock scheduler.Clock, arguments ...string) string {
+	t.Helper()
+	originalArgs := os.Args
+	originalStdout := os.Stdout
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	defer func() {
+		os.Args = originalArgs
+		os.Stdout = originalStdout
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	}()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = writer
+	os.Args = append([]string{"scheduler"}, arguments...)
+	scheduler.ExecuteWithClock(clock)
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+	return string(output)
+}
+
+func TestCLINextCommand(t *testing.T) {
+	clearRegistry()
+	err := RegisterTestTaskFactory("preview-task", NewTestTask("preview-task", scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}))
+	if err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	output := runCLI(t, scheduler.NewFakeClock(time.Now()), "--next", "all", "-n", "3", "--tz", "Asia/Tokyo", "--from", "2026-03-01T00:00:00Z", "--until", "2026-03-02")
+
+	expectedLines := []string{
+		"preview-task: Daily at 09:00 (UTC)",
+		"1. Sun, 2026-03-01 18:00:00 JST",
+		"2. Mon, 2026-03-02 18:00:00 JST",
+	}
+	for _, expectedLine := range expectedLines {
+		if !strings.Contains(output, expectedLine) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expectedLine, output)
+		}
+	}
+	if strings.Contains(output, "3. ") {
+		t.Errorf("Expected the --until date to limit the preview, got:\n%s", output)
+	}
+}
+
+func TestCLIReadsTimeFromClock(t *testing.T) {
+	clearRegistry()
+	err := RegisterTestTaskFactory("clocked-task", NewTestTask("clocked-task", scheduler.DailySchedule{Hour: 9, Minute: 0, Location: time.UTC}))
+	if err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC))
+
+	nextOutput := runCLI(t, fakeClock, "--next", "clocked-task", "-n", "2", "--tz", "UTC")
+	for _, expectedLine := range []string{"1. Thu, 2026-03-05 09:00:00 UTC", "2. Fri, 2026-03-06 09:00:00 UTC"} {
+		if !strings.Contains(nextOutput, expectedLine) {
+			t.Errorf("Expected --next output to contain %q, got:\n%s", expectedLine, nextOutput)
+		}
+	}
+
+	if listOutput := runCLI(t, fakeClock, "--list"); !strings.Contains(listOutput, "Tomorrow at 09:00 UTC") {
+		t.Errorf("Expected --list to show the next run relative to the clock, got:\n%s", listOutput)
+	}
+}
+
+func TestCLIListsOverdueOneTimeTaskUntilCompleted(t *testing.T) {
+	clearRegistry()
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC))
+	runTime := fakeClock.Now().Add(-time.Hour)
+	err := RegisterTestTaskFactory("overdue-task", NewTestTask("overdue-task", scheduler.NewOneTimeSchedule(runTime)))
+	if err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+
+	if output := runCLI(t, fakeClock, "--list"); !strings.Contains(output, "overdue, runs on --start") || strings.Contains(output, "invalid schedules") {
+		t.Errorf("Expected the overdue run to be listed as pending, got:\n%s", output)
+	}
+
+	completionsPath := filepath.Join(t.TempDir(), "completions.json")
+	completionStore, err := scheduler.NewFileCompletionStore(completionsPath)
+	if err != nil {
+		t.Fatalf("Failed to open completion store: %v", err)
+	}
+	if err := completionStore.MarkCompleted("overdue-task", runTime); err != nil {
+		t.Fatalf("Failed to record completion: %v", err)
+	}
+	if output := runCLI(t, fakeClock, "--list", "--completions", completionsPath); !strings.Contains(output, "invalid schedules (no future run time):\n  - overdue-task") {
+		t.Errorf("Expected the completed run not to be pending, got:\n%s", output)
+	}
+}
@@ -0,0 +1,256 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestRRuleScheduleOccurrences(t *testing.T) {
+	newYork := loadTestLocation(t, "America/New_York")
+	start := time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork) // Tuesday
+
+	testCases := []struct {
+		rule     string
+		expected []time.Time
+	}{
+		{
+			rule: "FREQ=DAILY;COUNT=3",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 3, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 4, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=19970918T130000Z",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 4, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 16, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 18, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "FREQ=MONTHLY;COUNT=4;BYDAY=-1FR",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 26, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.October, 31, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.November, 28, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=4",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 30, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.October, 31, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.November, 28, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=4",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 30, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.October, 1, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.October, 31, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU;COUNT=3",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1998, time.March, 29, 9, 0, 0, 0, newYork),
+				time.Date(1999, time.March, 28, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 2, 12, 0, 0, 0, newYork),
+			},
+		},
+		{
+			rule: "FREQ=DAILY;BYHOUR=9,17;BYMINUTE=30;COUNT=4",
+			expected: []time.Time{
+				time.Date(1997, time.September, 2, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.September, 2, 9, 30, 0, 0, newYork),
+				time.Date(1997, time.September, 2, 17, 30, 0, 0, newYork),
+				time.Date(1997, time.September, 3, 9, 30, 0, 0, newYork),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		schedule, err := scheduler.NewRRuleSchedule(testCase.rule, start)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", testCase.rule, err)
+		}
+		runTimes := collectRuns(schedule, start.Add(-time.Second), len(testCase.expected)+1)
+		expectedUTC := make([]time.Time, len(testCase.expected))
+		for index, expectedTime := range testCase.expected {
+			expectedUTC[index] = expectedTime.UTC()
+		}
+		assertRunTimes(t, testCase.rule, runTimes, expectedUTC)
+	}
+}
+
+func TestRRuleScheduleSkipsAheadWithoutCount(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 8, 0, 0, 0, time.UTC)
+	schedule, err := scheduler.NewRRuleSchedule("FREQ=WEEKLY;BYDAY=MO", start)
+	if err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+
+	nextRunTime := schedule.NextRun(time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC))
+	expected := time.Date(2026, time.March, 9, 8, 0, 0, 0, time.UTC)
+	if nextRunTime == nil || !nextRunTime.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, nextRunTime)
+	}
+}
+
+func TestRRuleScheduleExDatesAndRDates(t *testing.T) {
+	start := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+	schedule, err := scheduler.NewRRuleSchedule("FREQ=DAILY;COUNT=4", start)
+	if err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+	schedule.ExDates = []time.Time{time.Date(2026, time.January, 6, 9, 0, 0, 0, time.UTC)}
+	schedule.RDates = []time.Time{time.Date(2026, time.January, 20, 12, 0, 0, 0, time.UTC)}
+
+	runTimes := collectRuns(schedule, start.Add(-time.Second), 10)
+	assertRunTimes(t, "exdate and rdate", runTimes, []time.Time{
+		time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.January, 7, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.January, 8, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, time.January, 20, 12, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestRRuleScheduleInvalidRules(t *testing.T) {
+	invalidRules := []string{
+		"INTERVAL=2",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101T000000Z",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=YEARLY;BYWEEKNO=20",
+		"FREQ=DAILY;FREQ=WEEKLY",
+	}
+
+	for _, rule := range invalidRules {
+		_, err := scheduler.NewRRuleSchedule(rule, time.Now())
+		if !errors.Is(err, scheduler.ErrInvalidRRule) {
+			t.Errorf("Expected ErrInvalidRRule for %q, got: %v", rule, err)
+		}
+	}
+}
+
+func TestRRuleScheduleDescription(t *testing.T) {
+	start := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+	schedule, err := scheduler.NewRRuleSchedule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", start)
+	if err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+
+	expected := "Every 2 weeks on Monday and Wednesday at 09:00, 10 times, starting 2026-01-05 (UTC)"
+	if description := schedule.Description(); description != expected {
+		t.Errorf("Expected description %q, got %q", expected, description)
+	}
+}
+
+const testICalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Ops//Calendar//EN
+BEGIN:VEVENT
+UID:backup-nightly
+SUMMARY:Nightly backup\, primary cluster
+DTSTART;TZID=Europe/Berlin:20260105T020000
+RRULE:FREQ=DAILY;
+ BYDAY=MO,TU,WE,TH,FR
+EXDATE;TZID=Europe/Berlin:20260106T020000
+END:VEVENT
+BEGIN:VEVENT
+UID:quarterly-report
+SUMMARY:Quarterly report
+DTSTART:20260401T070000Z
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParseICalendar(t *testing.T) {
+	events, err := scheduler.ParseICalendar(strings.NewReader(testICalendar))
+	if err != nil {
+		t.Fatalf("Failed to parse calendar: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	backupEvent := events[0]
+	if backupEvent.UID != "backup-nightly" || backupEvent.Summary != "Nightly backup, primary cluster" {
+		t.Errorf("Unexpected event metadata: %+v", backupEvent)
+	}
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	runTimes := collectRuns(backupEvent.Schedule, time.Date(2026, time.January, 5, 3, 0, 0, 0, berlin), 2)
+	assertRunTimes(t, "backup event", runTimes, []time.Time{
+		time.Date(2026, time.January, 7, 2, 0, 0, 0, berlin).UTC(),
+		time.Date(2026, time.January, 8, 2, 0, 0, 0, berlin).UTC(),
+	})
+
+	reportRuns := collectRuns(events[1].Schedule, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), 2)
+	assertRunTimes(t, "single event", reportRuns, []time.Time{time.Date(2026, time.April, 1, 7, 0, 0, 0, time.UTC)})
+}
+
+func TestParseICalendarErrors(t *testing.T) {
+	invalidCalendars := []string{
+		"BEGIN:VEVENT\nUID:x\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART;TZID=Nowhere/City:20260101T000000\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20260101T000000Z\nRRULE:FREQ=SOMETIMES\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20260101T000000Z\n",
+		"BEGIN:VEVENT\nno separator here\nEND:VEVENT\n",
+	}
+
+	for _, calendarText := range invalidCalendars {
+		_, err := scheduler.ParseICalendar(strings.NewReader(calendarText))
+		if !errors.Is(err, scheduler.ErrInvalidICalendar) {
+			t.Errorf("Expected ErrInvalidICalendar for %q, got: %v", calendarText, err)
+		}
+	}
+}
+
+func TestRegisterICalendarTasks(t *testing.T) {
+	clearRegistry()
+	calendarPath := filepath.Join(t.TempDir(), "ops.ics")
+	if err := os.WriteFile(calendarPath, []byte(testICalendar), 0o600); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+
+	err := scheduler.RegisterICalendarTasks(calendarPath, func(event scheduler.ICalendarEvent) scheduler.Task {
+		return NewTestTask(event.TaskID(), event.Schedule)
+	})
+	if err != nil {
+		t.Fatalf("Failed to register calendar tasks: %v", err)
+	}
+
+	taskInfo, err := scheduler.GetTaskInfo("backup-nightly")
+	if err != nil {
+		t.Fatalf("Expected backup-nightly to be registered: %v", err)
+	}
+	if taskInfo.Description != "Nightly backup, primary cluster" {
+		t.Errorf("Unexpected description %q", taskInfo.Description)
+	}
+	if _, exists := scheduler.GetTaskFactory("quarterly-report"); !exists {
+		t.Errorf("Expected a factory for quarterly-report")
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+const benchmarkTaskCount = 10000
+
+// benchmarkTask marks a shared wait group done when it runs.
+type benchmarkTask struct {
+	*TestTask
+	completed *sync.WaitGroup
+}
+
+func (task benchmarkTask) Run(ctx context.Context) error {
+	task.completed.Done()
+	return nil
+}
+
+// discardLogs silences the default logger for the rest of the benchmark, so that it measures
+// scheduling rather than log output.
+func discardLogs(b *testing.B) {
+	previousLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+	b.Cleanup(func() { slog.SetDefault(previousLogger) })
+}
+
+// newBenchmarkScheduler returns a scheduler with benchmarkTaskCount tasks built by newSchedule.
+func newBenchmarkScheduler(b *testing.B, newSchedule func(index int) scheduler.TimeSchedule, completed *sync.WaitGroup) *scheduler.Scheduler {
+	b.Helper()
+	discardLogs(b)
+	schedulerInstance := scheduler.NewScheduler()
+	for index := 0; index < benchmarkTaskCount; index++ {
+		task := benchmarkTask{TestTask: NewTestTask(fmt.Sprintf("benchmark-task-%d", index), newSchedule(index)), completed: completed}
+		if err := schedulerInstance.RegisterTask(task); err != nil {
+			b.Fatalf("Failed to register task: %v", err)
+		}
+	}
+	return schedulerInstance
+}
+
+// BenchmarkSchedulerStartStop measures starting and stopping a scheduler whose tasks are not yet due.
+func BenchmarkSchedulerStartStop(b *testing.B) {
+	schedulerInstance := newBenchmarkScheduler(b, func(index int) scheduler.TimeSchedule {
+		return scheduler.IntervalSchedule{Interval: time.Hour + time.Duration(index)*time.Second}
+	}, &sync.WaitGroup{})
+
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		schedulerInstance.Start()
+		schedulerInstance.Stop()
+	}
+}
+
+// BenchmarkSchedulerDispatch measures dispatching one run of every task.
+func BenchmarkSchedulerDispatch(b *testing.B) {
+	for iteration := 0; iteration < b.N; iteration++ {
+		b.StopTimer()
+		var completed sync.WaitGroup
+		completed.Add(benchmarkTaskCount)
+		dueTime := time.Now()
+		schedulerInstance := newBenchmarkScheduler(b, func(index int) scheduler.TimeSchedule {
+			return scheduler.NewOneTimeSchedule(dueTime)
+		}, &completed)
+		b.StartTimer()
+
+		schedulerInstance.Start()
+		completed.Wait()
+		schedulerInstance.Stop()
+	}
+}
+
+// BenchmarkSchedulerRegisterWhileRunning measures registering tasks with a running scheduler.
+func BenchmarkSchedulerRegisterWhileRunning(b *testing.B) {
+	discardLogs(b)
+	schedulerInstance := scheduler.NewScheduler()
+	schedulerInstance.Start()
+	defer schedulerInstance.Stop()
+
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		task := NewTestTask(fmt.Sprintf("registered-task-%d", iteration), scheduler.IntervalSchedule{Interval: time.Hour})
+		if err := schedulerInstance.RegisterTask(task); err != nil {
+			b.Fatalf("Failed to register task: %v", err)
+		}
+	}
+}
+
+// BenchmarkBoundedScheduleMaxRuns measures stepping through every run of a schedule bounded by a
+// large MaxRuns, as the dispatcher and Preview do.
+func BenchmarkBoundedScheduleMaxRuns(b *testing.B) {
+	const maxRuns = 10000
+	notBefore := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	schedule := scheduler.BoundedSchedule{
+		Schedule:  scheduler.IntervalSchedule{Interval: time.Minute, StartTime: notBefore},
+		NotBefore: notBefore,
+		MaxRuns:   maxRuns,
+	}
+
+	for b.Loop() {
+		if runTimes := scheduler.Preview(schedule, notBefore.Add(-time.Minute), maxRuns+1); len(runTimes) != maxRuns {
+			b.Fatalf("Expected %d runs, got %d", maxRuns, len(runTimes))
+		}
+	}
+}
@@ -9,8 +9,11 @@ import (
 )
 
 func TestSchedulerIntegration(testContext *testing.T) {
+	// Drive the scheduler with a fake clock so that the test does not wait in real time
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+
 	// Create a schedule that will run soon
-	scheduleTime := time.Now().Add(200 * time.Millisecond)
+	scheduleTime := fakeClock.Now().Add(200 * time.Millisecond)
 	testSchedule := scheduler.NewOneTimeSchedule(scheduleTime)
 
 	// Create and configure the test task
@@ -23,7 +26,7 @@ func TestSchedulerIntegration(testContext *testing.T) {
 	}
 
 	// Create a new scheduler instance
-	schedulerInstance := scheduler.NewScheduler()
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock))
 
 	// Get the task information and register it with the scheduler
 	taskInfo, err := scheduler.GetTaskInfo("integration-test-task")
@@ -49,10 +52,16 @@ func TestSchedulerIntegration(testContext *testing.T) {
 	// Start the scheduler
 	schedulerInstance.Start()
 
-	// Wait for execution with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
+	// Once the dispatcher is waiting for the run, move the clock to the scheduled time
+	if !fakeClock.WaitForTimers(ctx, 1) {
+		testContext.Fatalf("Scheduler never waited for the scheduled run")
+	}
+	fakeClock.Advance(200 * time.Millisecond)
+
+	// Wait for execution with timeout
 	if !testSchedule.WaitForExecution(ctx) {
 		testContext.Fatalf("Task execution timed out")
 	}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// startBlockingTask starts a scheduler with a blocking task that runs once right away and
+// waits until the run is in progress.
+func startBlockingTask(t *testing.T, taskID string) (*scheduler.Scheduler, blockingTask, *scheduler.MemoryCompletionStore) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	schedulerInstance := scheduler.NewScheduler(scheduler.WithClock(fakeClock))
+	completionStore := scheduler.NewMemoryCompletionStore()
+	schedulerInstance.SetCompletionStore(completionStore)
+	task := blockingTask{TestTask: NewTestTask(taskID, scheduler.NewOneTimeSchedule(fakeClock.Now())), running: &atomic.Int32{}, release: make(chan struct{})}
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	waitForCondition(t, "the run to start", func() bool { return task.running.Load() == 1 })
+	return schedulerInstance, task, completionStore
+}
+
+func TestStopCancelsRunningTasks(t *testing.T) {
+	schedulerInstance, task, completionStore := startBlockingTask(t, "canceled-task")
+	if runningTasks := schedulerInstance.RunningTasks(); len(runningTasks) != 1 || runningTasks[0].TaskID != "canceled-task" {
+		t.Errorf("Expected the run to be reported as running, got %+v", runningTasks)
+	}
+
+	schedulerInstance.Stop()
+
+	if task.running.Load() != 0 {
+		t.Error("Expected Stop to wait for the canceled run to return")
+	}
+	if runningTasks := schedulerInstance.RunningTasks(); len(runningTasks) != 0 {
+		t.Errorf("Expected no running tasks after Stop, got %+v", runningTasks)
+	}
+	if completed, _ := completionStore.IsCompleted(task.ID(), task.Schedule().(scheduler.OneShotSchedule).RunTime()); completed {
+		t.Error("Expected the interrupted one-time run not to be marked completed")
+	}
+}
+
+func TestStopWithContextReportsTasksStillRunning(t *testing.T) {
+	schedulerInstance, task, _ := startBlockingTask(t, "stuck-at-shutdown")
+
+	shutdownContext, cancelShutdown := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShutdown()
+	err := schedulerInstance.StopWithContext(shutdownContext)
+
+	var shutdownError *scheduler.ShutdownError
+	if !errors.As(err, &shutdownError) || !errors.Is(err, scheduler.ErrShutdownTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a shutdown timeout, got %v", err)
+	}
+	if len(shutdownError.RunningTasks) != 1 || shutdownError.RunningTasks[0].TaskID != "stuck-at-shutdown" {
+		t.Errorf("Expected the stuck task to be reported, got %+v", shutdownError.RunningTasks)
+	}
+	waitForCondition(t, "the canceled run to return", func() bool { return task.running.Load() == 0 })
+}
+
+func TestStopWithContextWaitsForTasksToFinish(t *testing.T) {
+	schedulerInstance, task, completionStore := startBlockingTask(t, "finishing-task")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(task.release)
+	}()
+	if err := schedulerInstance.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected a clean shutdown, got %v", err)
+	}
+	if completed, _ := completionStore.IsCompleted(task.ID(), task.Schedule().(scheduler.OneShotSchedule).RunTime()); !completed {
+		t.Error("Expected the finished one-time run to be marked completed")
+	}
+}
+
+func TestStopCancelsRunTaskNow(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+	task := blockingTask{TestTask: NewTestTask("on-demand-at-shutdown", scheduler.DailySchedule{Hour: 3}), running: &atomic.Int32{}}
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+
+	runResult := make(chan error, 1)
+	go func() {
+		runResult <- schedulerInstance.RunTaskNow(task.ID())
+	}()
+	waitForCondition(t, "the on-demand run to start", func() bool { return task.running.Load() == 1 })
+	schedulerInstance.Stop()
+
+	if task.running.Load() != 0 {
+		t.Error("Expected Stop to wait for the on-demand run to return")
+	}
+	if err := <-runResult; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the on-demand run to be canceled, got %v", err)
+	}
+}
+
+// stubbornTask is a blocking task that ignores the cancellation of its context.
+type stubbornTask struct {
+	blockingTask
+}
+
+func (task stubbornTask) Run(ctx context.Context) error {
+	task.TestTask.Run(ctx)
+	task.running.Add(1)
+	defer task.running.Add(-1)
+	<-task.release
+	return nil
+}
+
+func TestStartAfterTimedOutStopDoesNotWaitForLeftoverRuns(t *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+	task := stubbornTask{blockingTask{TestTask: NewTestTask("ignores-cancellation", scheduler.DailySchedule{Hour: 3}), running: &atomic.Int32{}, release: make(chan struct{})}}
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	schedulerInstance.Start()
+	leftoverRun := runInBackground(schedulerInstance, task.ID())
+	waitForCondition(t, "the on-demand run to start", func() bool { return task.running.Load() == 1 })
+
+	shutdownContext, cancelShutdown := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShutdown()
+	if err := schedulerInstance.StopWithContext(shutdownContext); !errors.Is(err, scheduler.ErrShutdownTimeout) {
+		t.Fatalf("Expected a shutdown timeout, got %v", err)
+	}
+
+	schedulerInstance.Start()
+	stopped := make(chan struct{})
+	go func() {
+		schedulerInstance.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Expected Stop not to wait for the run left over from the previous Start")
+	}
+
+	close(task.release)
+	if err := <-leftoverRun; err != nil {
+		t.Errorf("Expected the leftover run to finish, got %v", err)
+	}
+	<-stopped
+}
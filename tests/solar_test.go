@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// assertNear fails unless actual is within a minute of expected, the accuracy of the solar formulas.
+func assertNear(t *testing.T, description string, actual *time.Time, expected time.Time) {
+	t.Helper()
+	if actual == nil {
+		t.Errorf("%s: expected %v, got nil", description, expected)
+		return
+	}
+	if difference := actual.Sub(expected); difference < -time.Minute || difference > time.Minute {
+		t.Errorf("%s: expected about %v, got %v", description, expected, actual)
+	}
+}
+
+func TestSolarScheduleEventsInBerlin(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	berlinSchedule := func(event scheduler.SolarEvent) scheduler.SolarSchedule {
+		return scheduler.SolarSchedule{Event: event, Latitude: 52.52, Longitude: 13.405, Location: berlin}
+	}
+	summerSolstice := time.Date(2026, time.June, 21, 0, 0, 0, 0, berlin)
+	winterSolstice := time.Date(2026, time.December, 21, 0, 0, 0, 0, berlin)
+
+	assertNear(t, "summer sunrise", berlinSchedule(scheduler.Sunrise).NextRun(summerSolstice), time.Date(2026, time.June, 21, 4, 43, 0, 0, berlin))
+	assertNear(t, "summer sunset", berlinSchedule(scheduler.Sunset).NextRun(summerSolstice), time.Date(2026, time.June, 21, 21, 33, 0, 0, berlin))
+	assertNear(t, "winter sunrise", berlinSchedule(scheduler.Sunrise).NextRun(winterSolstice), time.Date(2026, time.December, 21, 8, 15, 0, 0, berlin))
+	assertNear(t, "winter sunset", berlinSchedule(scheduler.Sunset).NextRun(winterSolstice), time.Date(2026, time.December, 21, 15, 54, 0, 0, berlin))
+	assertNear(t, "winter civil dawn", berlinSchedule(scheduler.CivilDawn).NextRun(winterSolstice), time.Date(2026, time.December, 21, 7, 33, 0, 0, berlin))
+	assertNear(t, "winter civil dusk", berlinSchedule(scheduler.CivilDusk).NextRun(winterSolstice), time.Date(2026, time.December, 21, 16, 35, 0, 0, berlin))
+
+	// After today's sunset, the next run is tomorrow's.
+	afterSunset := time.Date(2026, time.December, 21, 18, 0, 0, 0, berlin)
+	assertNear(t, "next day's sunset", berlinSchedule(scheduler.Sunset).NextRun(afterSunset), time.Date(2026, time.December, 22, 15, 54, 0, 0, berlin))
+}
+
+func TestSolarScheduleOffset(t *testing.T) {
+	sydney := loadTestLocation(t, "Australia/Sydney")
+	schedule := scheduler.SolarSchedule{Event: scheduler.Sunset, Latitude: -33.8688, Longitude: 151.2093, Location: sydney}.Before(30 * time.Minute)
+	assertNear(t, "30 minutes before sunset", schedule.NextRun(time.Date(2026, time.January, 1, 0, 0, 0, 0, sydney)), time.Date(2026, time.January, 1, 19, 39, 0, 0, sydney))
+
+	if description := schedule.Description(); description != "30m0s before sunset at 33.8688°S 151.2093°E (Australia/Sydney)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+}
+
+func TestSolarSchedulePolarDayAndNight(t *testing.T) {
+	oslo := loadTestLocation(t, "Europe/Oslo")
+	tromsoSunset := scheduler.SolarSchedule{Event: scheduler.Sunset, Latitude: 69.65, Longitude: 18.96, Location: oslo}
+	midsummer := time.Date(2026, time.June, 21, 12, 0, 0, 0, oslo)
+
+	// The sun does not set in Tromsø until late July.
+	nextSunset := tromsoSunset.NextRun(midsummer)
+	if nextSunset == nil || nextSunset.Before(time.Date(2026, time.July, 20, 0, 0, 0, 0, oslo)) {
+		t.Errorf("Expected polar days to be skipped, got %v", nextSunset)
+	}
+
+	tromsoSunset.Polar = scheduler.PolarNearest
+	nearestSunset := tromsoSunset.NextRun(midsummer)
+	if nearestSunset == nil || nearestSunset.Sub(midsummer) > 14*time.Hour {
+		t.Errorf("Expected the nearest time around the next solar midnight, got %v", nearestSunset)
+	}
+	if note := tromsoSunset.ExplainNextRun(midsummer); note != "no sunset during the polar day; running at solar midnight" {
+		t.Errorf("Unexpected polar day note %q", note)
+	}
+
+	tromsoSunrise := tromsoSunset
+	tromsoSunrise.Event = scheduler.Sunrise
+	midwinter := time.Date(2026, time.December, 21, 0, 0, 0, 0, oslo)
+	assertNear(t, "polar night sunrise at solar noon", tromsoSunrise.NextRun(midwinter), time.Date(2026, time.December, 21, 11, 42, 0, 0, oslo))
+	if note := tromsoSunrise.ExplainNextRun(midwinter); note != "no sunrise during the polar night; running at solar noon" {
+		t.Errorf("Unexpected polar night note %q", note)
+	}
+}
+
+func TestSolarScheduleValidationAndCodec(t *testing.T) {
+	err := scheduler.ValidateSchedule(scheduler.SolarSchedule{Event: scheduler.Sunrise, Latitude: 91})
+	if !errors.Is(err, scheduler.ErrInvalidSchedule) {
+		t.Errorf("Expected an out-of-range latitude to be rejected, got %v", err)
+	}
+
+	schedule := scheduler.SolarSchedule{Event: scheduler.CivilDusk, Latitude: 40.7128, Longitude: -74.006, Polar: scheduler.PolarNearest, Location: time.UTC}.After(15 * time.Minute)
+	data, err := scheduler.MarshalScheduleJSON(schedule)
+	if err != nil {
+		t.Fatalf("Failed to marshal solar schedule: %v", err)
+	}
+	expectedJSON := `{"type":"solar","event":"civil_dusk","latitude":40.7128,"longitude":-74.006,"offset":"15m0s","polar":"nearest","location":"UTC"}`
+	if string(data) != expectedJSON {
+		t.Errorf("Expected %s, got %s", expectedJSON, data)
+	}
+	decoded, err := scheduler.UnmarshalScheduleJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, schedule) {
+		t.Errorf("Expected %#v, got %#v", schedule, decoded)
+	}
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func mustOnCalendar(t *testing.T, expression string) scheduler.OnCalendarSchedule {
+	t.Helper()
+	schedule, err := scheduler.NewOnCalendarSchedule(expression)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", expression, err)
+	}
+	return schedule
+}
+
+func TestOnCalendarExpressions(t *testing.T) {
+	// Monday, March 2, 2026.
+	start := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		expression string
+		expected   []time.Time
+	}{
+		{"Mon..Fri *-*-* 09:00:00 UTC", []time.Time{
+			time.Date(2026, time.March, 3, 9, 0, 0, 0, time.UTC),
+			time.Date(2026, time.March, 4, 9, 0, 0, 0, time.UTC),
+		}},
+		{"*-*-01 00:00 UTC", []time.Time{
+			time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"hourly UTC", []time.Time{
+			time.Date(2026, time.March, 2, 11, 0, 0, 0, time.UTC),
+			time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC),
+		}},
+		{"Sat,Sun 10:30 UTC", []time.Time{
+			time.Date(2026, time.March, 7, 10, 30, 0, 0, time.UTC),
+			time.Date(2026, time.March, 8, 10, 30, 0, 0, time.UTC),
+		}},
+		{"*:0/20 UTC", []time.Time{
+			time.Date(2026, time.March, 2, 10, 20, 0, 0, time.UTC),
+			time.Date(2026, time.March, 2, 10, 40, 0, 0, time.UTC),
+		}},
+		{"*-*~01 18:00 UTC", []time.Time{
+			time.Date(2026, time.March, 31, 18, 0, 0, 0, time.UTC),
+			time.Date(2026, time.April, 30, 18, 0, 0, 0, time.UTC),
+		}},
+		{"quarterly UTC", []time.Time{
+			time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"2026-06-01..03 12:00 UTC", []time.Time{
+			time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+	for _, testCase := range testCases {
+		schedule := mustOnCalendar(t, testCase.expression)
+		assertRunTimes(t, testCase.expression, collectRuns(schedule, start, len(testCase.expected)), testCase.expected)
+	}
+}
+
+func TestOnCalendarRequiresBothWeekdayAndDate(t *testing.T) {
+	schedule := mustOnCalendar(t, "Fri *-*-13 UTC")
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	assertRunTimes(t, "Friday the 13th", collectRuns(schedule, start, 2), []time.Time{
+		time.Date(2026, time.February, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 13, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestOnCalendarTimeZone(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	schedule := mustOnCalendar(t, "daily Europe/Berlin")
+	if schedule.Location == nil || schedule.Location.String() != "Europe/Berlin" {
+		t.Fatalf("Expected the Europe/Berlin location, got %v", schedule.Location)
+	}
+	nextRunTime := schedule.NextRun(time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC))
+	if nextRunTime == nil || !nextRunTime.Equal(time.Date(2026, time.March, 3, 0, 0, 0, 0, berlin)) {
+		t.Errorf("Expected midnight in Berlin, got %v", nextRunTime)
+	}
+	if description := schedule.Description(); description != "OnCalendar=*-*-* 00:00:00 (Europe/Berlin)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+}
+
+func TestOnCalendarInvalidExpressions(t *testing.T) {
+	for _, expression := range []string{"", "Mon..Funday", "*-13-01", "25:00", "*-*-* 10:00 Mars/Olympus", "Mon 10:00 extra UTC"} {
+		if _, err := scheduler.NewOnCalendarSchedule(expression); !errors.Is(err, scheduler.ErrInvalidOnCalendar) {
+			t.Errorf("Expected ErrInvalidOnCalendar for %q, got %v", expression, err)
+		}
+	}
+}
+
+func TestOnCalendarParsingAndCodec(t *testing.T) {
+	schedule, err := scheduler.ParseSchedule("OnCalendar=Mon..Fri 09:00 UTC")
+	if err != nil {
+		t.Fatalf("Failed to parse OnCalendar phrase: %v", err)
+	}
+	if description := schedule.Description(); description != "OnCalendar=Mon..Fri *-*-* 09:00:00 (UTC)" {
+		t.Errorf("Unexpected description %q", description)
+	}
+	if _, err := scheduler.ParseSchedule("oncalendar Mon..Fri 99:00"); !errors.Is(err, scheduler.ErrInvalidOnCalendar) || !errors.Is(err, scheduler.ErrInvalidScheduleSyntax) {
+		t.Errorf("Expected a syntax error wrapping ErrInvalidOnCalendar, got %v", err)
+	}
+
+	data, err := scheduler.MarshalScheduleJSON(schedule)
+	if err != nil {
+		t.Fatalf("Failed to marshal OnCalendar schedule: %v", err)
+	}
+	decoded, err := scheduler.UnmarshalScheduleJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, schedule) {
+		t.Errorf("Expected %s to round-trip, got %#v", data, decoded)
+	}
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+// timedTask blocks in Run until its own timeout expires.
+type timedTask struct {
+	blockingTask
+	timeout time.Duration
+}
+
+func (task timedTask) Timeout() time.Duration {
+	return task.timeout
+}
+
+// slowBeforeTask blocks in BeforeExecute until its own timeout expires.
+type slowBeforeTask struct {
+	*TestTask
+	beforeTimeout time.Duration
+}
+
+func (task slowBeforeTask) BeforeExecute(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (task slowBeforeTask) BeforeExecuteTimeout() time.Duration {
+	return task.beforeTimeout
+}
+
+// deadlineTask blocks in Run and must finish within window of its scheduled time.
+type deadlineTask struct {
+	blockingTask
+	window time.Duration
+}
+
+func (task deadlineTask) Deadline(scheduledAt time.Time) time.Time {
+	return scheduledAt.Add(task.window)
+}
+
+// patientTask disables its timeout and finishes after a delay.
+type patientTask struct {
+	*TestTask
+}
+
+func (task patientTask) Timeout() time.Duration {
+	return 0
+}
+
+func (task patientTask) Run(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(20 * time.Millisecond):
+		return task.TestTask.Run(ctx)
+	}
+}
+
+// runOnce registers the task with a new scheduler and runs it on demand, returning the event
+// reported to the error handler and the run's error.
+func runOnce(t *testing.T, task scheduler.Task, options ...scheduler.Option) (scheduler.RunEvent, error) {
+	t.Helper()
+	var failedEvent scheduler.RunEvent
+	options = append(options, scheduler.WithErrorHandler(func(event scheduler.RunEvent) { failedEvent = event }))
+	schedulerInstance := scheduler.NewScheduler(options...)
+	if err := schedulerInstance.RegisterTask(task); err != nil {
+		t.Fatalf("Failed to register task: %v", err)
+	}
+	runError := schedulerInstance.RunTaskNow(task.ID())
+	return failedEvent, runError
+}
+
+func TestTaskRunTimeout(t *testing.T) {
+	task := timedTask{blockingTask{TestTask: NewTestTask("timed-task", scheduler.DailySchedule{Hour: 3}), running: &atomic.Int32{}}, 10 * time.Millisecond}
+	failedEvent, runError := runOnce(t, task)
+
+	var timeoutError *scheduler.TimeoutError
+	if !errors.As(runError, &timeoutError) || timeoutError.Phase != "Run" || timeoutError.Timeout != 10*time.Millisecond {
+		t.Fatalf("Expected a Run timeout after 10ms, got %v", runError)
+	}
+	if !errors.Is(runError, scheduler.ErrTaskTimeout) || !errors.Is(runError, context.DeadlineExceeded) {
+		t.Errorf("Expected the error to wrap ErrTaskTimeout and the context error, got %v", runError)
+	}
+	if !failedEvent.TimedOut || failedEvent.Attempts != 2 {
+		t.Errorf("Expected a timed-out run after two attempts, got %+v", failedEvent)
+	}
+	if expected := `task "timed-task": Run timed out after 10ms: context deadline exceeded`; runError.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, runError.Error())
+	}
+}
+
+func TestTaskBeforeExecuteTimeout(t *testing.T) {
+	task := slowBeforeTask{NewTestTask("slow-before-task", scheduler.DailySchedule{Hour: 3}), 10 * time.Millisecond}
+	failedEvent, runError := runOnce(t, task)
+
+	var timeoutError *scheduler.TimeoutError
+	if !errors.As(runError, &timeoutError) || timeoutError.Phase != "BeforeExecute" {
+		t.Fatalf("Expected a BeforeExecute timeout, got %v", runError)
+	}
+	if !failedEvent.TimedOut || failedEvent.Attempts != 0 || task.GetExecutionCount() != 0 {
+		t.Errorf("Expected Run not to be called, got %+v", failedEvent)
+	}
+}
+
+func TestTaskDeadlineStopsRetries(t *testing.T) {
+	task := deadlineTask{blockingTask{TestTask: NewTestTask("deadline-task", scheduler.DailySchedule{Hour: 3}), running: &atomic.Int32{}}, 20 * time.Millisecond}
+	failedEvent, runError := runOnce(t, task)
+
+	var timeoutError *scheduler.TimeoutError
+	if !errors.As(runError, &timeoutError) || timeoutError.Deadline.IsZero() || timeoutError.Timeout != 0 {
+		t.Fatalf("Expected the run to miss its deadline, got %v", runError)
+	}
+	if failedEvent.Attempts != 1 || task.GetExecutionCount() != 1 {
+		t.Errorf("Expected no retry after the deadline, got %d attempts", failedEvent.Attempts)
+	}
+}
+
+func TestTaskTimeoutOverridesSchedulerDefault(t *testing.T) {
+	task := patientTask{NewTestTask("patient-task", scheduler.DailySchedule{Hour: 3})}
+	if _, runError := runOnce(t, task, scheduler.WithRunTimeout(time.Millisecond)); runError != nil {
+		t.Errorf("Expected a task without a timeout to finish, got %v", runError)
+	}
+}
+
+func TestOrdinaryFailureIsNotATimeout(t *testing.T) {
+	fakeClock := scheduler.NewFakeClock(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	task := failingTask{NewTestTask("ordinary-failure", scheduler.DailySchedule{Hour: 3})}
+	runResult := make(chan error, 1)
+	var failedEvent scheduler.RunEvent
+	go func() {
+		var runError error
+		failedEvent, runError = runOnce(t, task, scheduler.WithClock(fakeClock))
+		runResult <- runError
+	}()
+	waitForTimers(t, fakeClock, 1)
+	fakeClock.Advance(100 * time.Millisecond)
+
+	if runError := <-runResult; runError == nil || errors.Is(runError, scheduler.ErrTaskTimeout) || failedEvent.TimedOut {
+		t.Errorf("Expected an ordinary failure, got %v (timed out: %t)", runError, failedEvent.TimedOut)
+	}
+}
+
+func TestTaskDeadlineFollowsTheClock(t *testing.T) {
+	startTime := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	fakeClock := scheduler.NewFakeClock(startTime)
+	task := deadlineTask{blockingTask{TestTask: NewTestTask("clocked-deadline-task", scheduler.DailySchedule{Hour: 3}), running: &atomic.Int32{}}, time.Hour}
+	runResult := make(chan error, 1)
+	go func() {
+		_, runError := runOnce(t, task, scheduler.WithClock(fakeClock), scheduler.WithRunTimeout(0))
+		runResult <- runError
+	}()
+	waitForCondition(t, "the run to start", func() bool { return task.running.Load() == 1 })
+	waitForTimers(t, fakeClock, 1)
+
+	fakeClock.Advance(time.Hour)
+	select {
+	case runError := <-runResult:
+		var timeoutError *scheduler.TimeoutError
+		if !errors.As(runError, &timeoutError) || !timeoutError.Deadline.Equal(startTime.Add(time.Hour)) {
+			t.Errorf("Expected the run to miss its deadline, got %v", runError)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected advancing the clock past the deadline to end the run")
+	}
+}
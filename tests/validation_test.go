@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyemirov/scheduler/pkg/scheduler"
+)
+
+func TestValidateScheduleRejectsInvalidFields(testContext *testing.T) {
+	validDaily := scheduler.DailySchedule{Hour: 9, Minute: 0}
+	testCases := []struct {
+		description string
+		schedule    scheduler.TimeSchedule
+		field       string
+	}{
+		{"hour out of range", scheduler.DailySchedule{Hour: 25}, "DailySchedule.Hour"},
+		{"negative minute", scheduler.DailySchedule{Hour: 9, Minute: -1}, "DailySchedule.Minute"},
+		{"no weekdays", scheduler.WeekdaySchedule{Hour: 9}, "WeekdaySchedule.Weekdays"},
+		{"no monthly days", scheduler.MonthlySchedule{Hour: 9}, "MonthlySchedule.Days"},
+		{"day zero", scheduler.MonthlySchedule{Days: []int{0}}, "MonthlySchedule.Days[0]"},
+		{"sixth weekday", scheduler.MonthlySchedule{NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Friday, N: 6}}}, "MonthlySchedule.NthWeekdays[0].N"},
+		{"zero interval", scheduler.IntervalSchedule{}, "IntervalSchedule.Interval"},
+		{"negative interval", scheduler.IntervalSchedule{Interval: -time.Minute}, "IntervalSchedule.Interval"},
+		{"zero one-time schedule", &scheduler.OneTimeSchedule{}, "OneTimeSchedule.runTime"},
+		{"unparsed cron", scheduler.CronSchedule{}, "CronSchedule.expression"},
+		{"unparsed rrule", scheduler.RRuleSchedule{Start: time.Now()}, "RRuleSchedule.rule"},
+		{"bounded without schedule", scheduler.BoundedSchedule{}, "BoundedSchedule.Schedule"},
+		{"max runs without start", scheduler.BoundedSchedule{Schedule: validDaily, MaxRuns: 2}, "BoundedSchedule.NotBefore"},
+		{"negative jitter", scheduler.WithJitter(validDaily, -time.Minute, "seed"), "JitterSchedule.MaxJitter"},
+		{"empty any-of", scheduler.AnyOf(), "AnyOfSchedule.Schedules"},
+		{"window past midnight", scheduler.Within(validDaily, scheduler.Between(9*time.Hour, 25*time.Hour)), "TimeOfDayWindow.End"},
+		{"business days without calendar", scheduler.BusinessDaySchedule{Schedule: validDaily}, "BusinessDaySchedule.Calendar"},
+	}
+
+	for _, testCase := range testCases {
+		err := scheduler.ValidateSchedule(testCase.schedule)
+		if !errors.Is(err, scheduler.ErrInvalidSchedule) {
+			testContext.Errorf("%s: expected ErrInvalidSchedule, got %v", testCase.description, err)
+			continue
+		}
+		var fieldError *scheduler.ScheduleFieldError
+		if !errors.As(err, &fieldError) {
+			testContext.Errorf("%s: expected a ScheduleFieldError, got %v", testCase.description, err)
+			continue
+		}
+		if field := fieldError.Schedule + "." + fieldError.Field; field != testCase.field {
+			testContext.Errorf("%s: expected field %s, got %s (%v)", testCase.description, testCase.field, field, err)
+		}
+	}
+}
+
+func TestValidateScheduleNamesNestedField(testContext *testing.T) {
+	schedule := scheduler.AnyOf(
+		scheduler.DailySchedule{Hour: 9},
+		scheduler.LimitRuns(scheduler.IntervalSchedule{}, time.Now(), 3),
+	)
+	err := scheduler.ValidateSchedule(schedule)
+	if err == nil || !strings.Contains(err.Error(), "AnyOfSchedule.Schedules[1]: BoundedSchedule.Schedule: invalid schedule: IntervalSchedule.Interval") {
+		testContext.Errorf("Expected the nested field path in the error, got %v", err)
+	}
+}
+
+func TestValidateScheduleAcceptsValidSchedules(testContext *testing.T) {
+	validSchedules := []scheduler.TimeSchedule{
+		scheduler.DailySchedule{Hour: 23, Minute: 59},
+		scheduler.WeekdaySchedule{Weekdays: []time.Weekday{time.Monday}, Hour: 9},
+		scheduler.MonthlySchedule{Days: []int{-1}, NthWeekdays: []scheduler.NthWeekday{{Weekday: time.Tuesday, N: 2}}},
+		scheduler.IntervalSchedule{Interval: time.Minute},
+		scheduler.NewOneTimeSchedule(time.Now().Add(time.Hour)),
+		scheduler.MustCronSchedule("*/5 * * * *"),
+		scheduler.LimitRuns(scheduler.DailySchedule{Hour: 9}, time.Now(), 3),
+		scheduler.Within(scheduler.IntervalSchedule{Interval: time.Hour}, scheduler.Between(9*time.Hour, 17*time.Hour).On(time.Monday)),
+		scheduler.BusinessDaySchedule{Schedule: scheduler.DailySchedule{Hour: 9}, Calendar: scheduler.HolidayCalendar{}},
+	}
+	for _, schedule := range validSchedules {
+		if err := scheduler.ValidateSchedule(schedule); err != nil {
+			testContext.Errorf("Expected %q to be valid, got %v", schedule.Description(), err)
+		}
+	}
+}
+
+func TestInvalidSchedulesDoNotPanicOrHang(testContext *testing.T) {
+	start := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	if nextRunTime := (scheduler.IntervalSchedule{}).NextRun(start); nextRunTime != nil {
+		testContext.Errorf("Expected a zero interval to never run, got %v", nextRunTime)
+	}
+	if nextRunTime := (scheduler.WeekdaySchedule{Hour: 9}).NextRun(start); nextRunTime != nil {
+		testContext.Errorf("Expected a schedule without weekdays to never run, got %v", nextRunTime)
+	}
+}
+
+func TestRegisterTaskInfoRejectsInvalidSchedule(testContext *testing.T) {
+	clearRegistry()
+
+	err := scheduler.RegisterTaskInfo("invalid-interval", "Invalid", scheduler.IntervalSchedule{})
+	if !errors.Is(err, scheduler.ErrInvalidSchedule) {
+		testContext.Fatalf("Expected ErrInvalidSchedule, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `task "invalid-interval"`) {
+		testContext.Errorf("Expected the task ID in the error, got %v", err)
+	}
+	if _, err := scheduler.GetTaskInfo("invalid-interval"); !errors.Is(err, scheduler.ErrTaskNotFound) {
+		testContext.Errorf("Expected the invalid task not to be registered, got %v", err)
+	}
+}
+
+func TestSchedulerRegisterTaskRejectsInvalidSchedule(testContext *testing.T) {
+	schedulerInstance := scheduler.NewScheduler()
+
+	err := schedulerInstance.RegisterTask(NewTestTask("no-weekdays", scheduler.WeekdaySchedule{Hour: 9}))
+	if !errors.Is(err, scheduler.ErrInvalidSchedule) {
+		testContext.Errorf("Expected ErrInvalidSchedule, got %v", err)
+	}
+	err = schedulerInstance.RegisterTask(NewTestTask("no-schedule", nil))
+	if !errors.Is(err, scheduler.ErrInvalidSchedule) {
+		testContext.Errorf("Expected ErrInvalidSchedule for a nil schedule, got %v", err)
+	}
+	if err := schedulerInstance.RegisterTask(NewTestTask("valid", scheduler.DailySchedule{Hour: 9})); err != nil {
+		testContext.Errorf("Expected a valid task to register, got %v", err)
+	}
+}